@@ -9,7 +9,9 @@ import (
 var applyEntryCmd = &cobra.Command{
 	Use:   "entry",
 	Short: "Applies a configuration to an entry",
-	Long: `Applies a configuration to an entry in Pleasant Password Server. Takes a JSON string as input.
+	Long: `Applies a configuration to an entry in Pleasant Password Server. Takes JSON as input.
+The JSON can be passed as a string with --data, read from a file with '--data @FILE' or from stdin with '--data -'.
+With --file, the input is read from a YAML or JSON file, use '--file -' for stdin.
 If the entry does not exist, it is created using the supplied configuration
 and the command returns the entry's id. If it exists, any changes will be applied to the entry.
 Partial updates are allowed e.g. only supplying 'Name' and 'Password' to update the password.
@@ -31,15 +33,18 @@ pleasant-cli apply entry --path 'Root/Folder1/TestEntry' --data '
     "Name": "TestEntry",
     "Username": "MyNewUserName",
     "Password": "MyNewPassword01"
-}'`,
+}'
+
+pleasant-cli apply entry --path 'Root/Folder1/TestEntry' --file entry.yaml
+sops -d entry.enc.yaml | pleasant-cli apply entry --path 'Root/Folder1/TestEntry' --file -`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
+		client := newClient()
 
-		json, err := cmd.Flags().GetString("data")
+		json, err := readData(cmd)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
@@ -55,13 +60,13 @@ pleasant-cli apply entry --path 'Root/Folder1/TestEntry' --data '
 				pleasant.ExitFatal(err)
 			}
 
-			pid, err := pleasant.GetParentIdByResourcePath(baseUrl, resourcePath, bearerToken)
+			pid, err := client.GetParentIdByResourcePath(cmd.Context(), resourcePath)
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
 
 			if !pleasant.PathAndNameMatching(resourcePath, input.Name) {
-				pleasant.ExitFatal("error: entry name from path and data do not match")
+				pleasant.ExitFatal(pleasant.ErrNameMismatch)
 			}
 
 			input.GroupId = pid
@@ -74,7 +79,7 @@ pleasant-cli apply entry --path 'Root/Folder1/TestEntry' --data '
 			json = j
 		}
 
-		id, err := pleasant.DuplicateEntryId(baseUrl, json, bearerToken)
+		id, err := client.DuplicateEntryId(cmd.Context(), json)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
@@ -82,7 +87,7 @@ pleasant-cli apply entry --path 'Root/Folder1/TestEntry' --data '
 		if id != "" {
 			subPath := pleasant.PathEntry + "/" + id
 
-			_, err := pleasant.PatchJsonString(baseUrl, subPath, json, bearerToken)
+			_, err := client.PatchJsonString(cmd.Context(), subPath, json)
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
@@ -90,7 +95,7 @@ pleasant-cli apply entry --path 'Root/Folder1/TestEntry' --data '
 			pleasant.Exit("Existing entry with id", id, "patched")
 		}
 
-		id, err = pleasant.PostJsonString(baseUrl, pleasant.PathEntry, json, bearerToken)
+		id, err = client.PostJsonString(cmd.Context(), pleasant.PathEntry, json)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
@@ -102,11 +107,10 @@ pleasant-cli apply entry --path 'Root/Folder1/TestEntry' --data '
 func init() {
 	applyCmd.AddCommand(applyEntryCmd)
 
-	applyEntryCmd.Flags().StringP("data", "d", "", "JSON string with entry data")
+	addDataFlags(applyEntryCmd, "JSON string with entry data")
 	applyEntryCmd.Flags().StringP("path", "p", "", "Path to entry")
-	applyEntryCmd.MarkFlagRequired("data")
 
 	applyEntryCmd.RegisterFlagCompletionFunc("path", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
-		return pleasant.CompletePathFlag(toComplete, true)
+		return completePathFlag(cmd.Context(), toComplete, true)
 	})
 }
@@ -9,7 +9,9 @@ import (
 var applyFolderCmd = &cobra.Command{
 	Use:   "folder",
 	Short: "Applies a configuration to a folder",
-	Long: `Applies a configuration to a folder in Pleasant Password Server. Takes a JSON string as input.
+	Long: `Applies a configuration to a folder in Pleasant Password Server. Takes JSON as input.
+The JSON can be passed as a string with --data, read from a file with '--data @FILE' or from stdin with '--data -'.
+With --file, the input is read from a YAML or JSON file, use '--file -' for stdin.
 If the folder does not exist, it is created using the supplied configuration
 and the command returns the folder's id. If it exists, any changes will be applied to the folder.
 Partial updates are allowed e.g. only supplying 'Name' and 'Notes' to update the notes.
@@ -29,15 +31,17 @@ pleasant-cli apply folder --path 'Root/Folder1/TestFolder' --data '
 {
     "Notes": "New note for the folder",
     "Name": "TestFolder"
-}'`,
+}'
+
+pleasant-cli apply folder --path 'Root/Folder1/TestFolder' --file folder.yaml`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
+		client := newClient()
 
-		json, err := cmd.Flags().GetString("data")
+		json, err := readData(cmd)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
@@ -53,13 +57,13 @@ pleasant-cli apply folder --path 'Root/Folder1/TestFolder' --data '
 				pleasant.ExitFatal(err)
 			}
 
-			pid, err := pleasant.GetParentIdByResourcePath(baseUrl, resourcePath, bearerToken)
+			pid, err := client.GetParentIdByResourcePath(cmd.Context(), resourcePath)
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
 
 			if !pleasant.PathAndNameMatching(resourcePath, input.Name) {
-				pleasant.ExitFatal("error: folder name from path and data do not match")
+				pleasant.ExitFatal(pleasant.ErrNameMismatch)
 			}
 
 			input.ParentId = pid
@@ -72,7 +76,7 @@ pleasant-cli apply folder --path 'Root/Folder1/TestFolder' --data '
 			json = j
 		}
 
-		id, err := pleasant.DuplicateFolderId(baseUrl, json, bearerToken)
+		id, err := client.DuplicateFolderId(cmd.Context(), json)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
@@ -80,7 +84,7 @@ pleasant-cli apply folder --path 'Root/Folder1/TestFolder' --data '
 		if id != "" {
 			subPath := pleasant.PathFolders + "/" + id
 
-			_, err := pleasant.PatchJsonString(baseUrl, subPath, json, bearerToken)
+			_, err := client.PatchJsonString(cmd.Context(), subPath, json)
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
@@ -88,7 +92,7 @@ pleasant-cli apply folder --path 'Root/Folder1/TestFolder' --data '
 			pleasant.Exit("Existing folder with id", id, "patched")
 		}
 
-		id, err = pleasant.PostJsonString(baseUrl, pleasant.PathFolders, json, bearerToken)
+		id, err = client.PostJsonString(cmd.Context(), pleasant.PathFolders, json)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
@@ -100,11 +104,10 @@ pleasant-cli apply folder --path 'Root/Folder1/TestFolder' --data '
 func init() {
 	applyCmd.AddCommand(applyFolderCmd)
 
-	applyFolderCmd.Flags().StringP("data", "d", "", "JSON string with folder data")
+	addDataFlags(applyFolderCmd, "JSON string with folder data")
 	applyFolderCmd.Flags().StringP("path", "p", "", "Path to folder")
-	applyFolderCmd.MarkFlagRequired("data")
 
 	applyFolderCmd.RegisterFlagCompletionFunc("path", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
-		return pleasant.CompletePathFlag(toComplete, false)
+		return completePathFlag(cmd.Context(), toComplete, false)
 	})
 }
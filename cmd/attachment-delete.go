@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+// attachmentDeleteCmd represents the attachment delete command
+var attachmentDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Deletes an attachment of an entry",
+	Long: `Deletes an attachment of an entry by its id or file name.
+A path must be absolute and starts with 'Root/', e.g. 'Root/Folder1/Folder2/Entry'.
+
+Examples:
+pleasant-cli attachment delete --path <path> --name kubeconfig.yaml
+pleasant-cli attachment delete --id <id> --attachment-id <attachment id>`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
+			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
+		}
+
+		client := newClient()
+
+		id, err := entryIdFromFlags(cmd, client)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		attachment, err := attachmentFromFlags(cmd.Context(), cmd, client, id)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		err = client.DeleteEntryAttachment(cmd.Context(), id, attachment.AttachmentId)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		pleasant.Exit("Attachment deleted:", attachment.FileName)
+	},
+}
+
+func init() {
+	attachmentCmd.AddCommand(attachmentDeleteCmd)
+
+	addEntryFlags(attachmentDeleteCmd)
+	addAttachmentFlags(attachmentDeleteCmd)
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+// attachmentGetCmd represents the attachment get command
+var attachmentGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Downloads an attachment of an entry",
+	Long: `Downloads an attachment of an entry by its id or file name.
+A path must be absolute and starts with 'Root/', e.g. 'Root/Folder1/Folder2/Entry'.
+
+The attachment is saved to the file given with --file, or to its file name in the current directory.
+Use '--file -' to write it to stdout. Files are created with permissions 0600 and existing files
+are only replaced with --force, the replaced file gets permissions 0600 as well.
+
+Examples:
+pleasant-cli attachment get --path <path> --name kubeconfig.yaml
+pleasant-cli attachment get --path <path> --name kubeconfig.yaml --file ~/.kube/config --force
+pleasant-cli attachment get --id <id> --attachment-id <attachment id> --file -`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
+			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
+		}
+
+		file, err := cmd.Flags().GetString("file")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		force, err := cmd.Flags().GetBool("force")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		client := newClient()
+
+		id, err := entryIdFromFlags(cmd, client)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		attachment, err := attachmentFromFlags(cmd.Context(), cmd, client, id)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		if file == "-" {
+			_, err = os.Stdout.Write(attachment.FileData)
+			if err != nil {
+				pleasant.ExitFatal(err)
+			}
+
+			return
+		}
+
+		if file == "" {
+			// Do not let the file name from the server point outside of the current directory
+			file = filepath.Base(filepath.Clean("/" + attachment.FileName))
+			if file == string(filepath.Separator) || file == "." {
+				pleasant.ExitFatal(pleasant.ErrAttachmentNoFileName)
+			}
+		}
+
+		if force {
+			// An existing file is replaced, so it does not keep permissions that are too open
+			err = pleasant.WritePrivateFile(file, attachment.FileData)
+		} else {
+			err = writeNewFile(file, attachment.FileData)
+		}
+
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		pleasant.Exit("Attachment saved to:", file)
+	},
+}
+
+// writeNewFile writes data to a new file that is only accessible by the current user, an existing file is an error
+func writeNewFile(file string, data []byte) error {
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(data)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func init() {
+	attachmentCmd.AddCommand(attachmentGetCmd)
+
+	addEntryFlags(attachmentGetCmd)
+	addAttachmentFlags(attachmentGetCmd)
+
+	attachmentGetCmd.Flags().StringP("file", "f", "", "File to save the attachment to, '-' for stdout (default is the file name of the attachment)")
+	attachmentGetCmd.Flags().Bool("force", false, "Overwrite an existing file")
+}
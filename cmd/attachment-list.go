@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+// attachmentListCmd represents the attachment list command
+var attachmentListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the attachments of an entry",
+	Long: `Lists the id, file name and size of the attachments of an entry.
+A path must be absolute and starts with 'Root/', e.g. 'Root/Folder1/Folder2/Entry'.
+
+Examples:
+pleasant-cli attachment list --path <path>
+pleasant-cli attachment list --id <id>`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
+			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
+		}
+
+		client := newClient()
+
+		id, err := entryIdFromFlags(cmd, client)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		attachments, err := client.GetEntryAttachments(cmd.Context(), id)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME\tSIZE")
+
+		for _, a := range attachments {
+			fmt.Fprintf(tw, "%v\t%v\t%v\n", a.AttachmentId, a.FileName, a.FileSize)
+		}
+
+		tw.Flush()
+	},
+}
+
+func init() {
+	attachmentCmd.AddCommand(attachmentListCmd)
+
+	addEntryFlags(attachmentListCmd)
+}
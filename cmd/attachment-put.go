@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+// attachmentPutCmd represents the attachment put command
+var attachmentPutCmd = &cobra.Command{
+	Use:   "put",
+	Short: "Uploads a file as attachment of an entry",
+	Long: `Uploads a file as attachment of an entry.
+A path must be absolute and starts with 'Root/', e.g. 'Root/Folder1/Folder2/Entry'.
+Returns the id of the attachment if successful.
+
+The attachment gets the file name of the uploaded file, unless --name is used.
+Use '--file -' to read the file from stdin, --name is then required.
+
+Examples:
+pleasant-cli attachment put --path <path> --file ./keystore.jks
+pleasant-cli attachment put --id <id> --file ./config.yaml --name kubeconfig.yaml
+kubectl config view --raw | pleasant-cli attachment put --path <path> --file - --name kubeconfig.yaml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
+			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
+		}
+
+		file, err := cmd.Flags().GetString("file")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		var data []byte
+
+		if file == "-" {
+			if name == "" {
+				pleasant.ExitFatal(pleasant.ErrAttachmentName)
+			}
+
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(file)
+		}
+
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		if name == "" {
+			name = filepath.Base(file)
+		}
+
+		client := newClient()
+
+		id, err := entryIdFromFlags(cmd, client)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		attachmentId, err := client.CreateEntryAttachment(cmd.Context(), id, &pleasant.Attachment{
+			FileName: name,
+			FileData: data,
+		})
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		pleasant.Exit(attachmentId)
+	},
+}
+
+func init() {
+	attachmentCmd.AddCommand(attachmentPutCmd)
+
+	addEntryFlags(attachmentPutCmd)
+
+	attachmentPutCmd.Flags().StringP("file", "f", "", "File to upload, '-' for stdin")
+	attachmentPutCmd.Flags().StringP("name", "n", "", "File name of the attachment (default is the name of the uploaded file)")
+	attachmentPutCmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+// attachmentCmd represents the attachment command
+var attachmentCmd = &cobra.Command{
+	Use:   "attachment",
+	Short: "Lists, downloads, uploads or deletes attachments of entries",
+	Long:  `Lists, downloads, uploads or deletes attachments of entries`,
+	Args:  cobra.MatchAll(cobra.MinimumNArgs(1)),
+	Run: func(cmd *cobra.Command, args []string) {
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(attachmentCmd)
+}
+
+// addEntryFlags adds the --path and --id flags that select the entry of an attachment command
+func addEntryFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("path", "p", "", "Path to entry")
+	cmd.Flags().StringP("id", "i", "", "Id of entry")
+	cmd.MarkFlagsMutuallyExclusive("path", "id")
+	cmd.MarkFlagsOneRequired("path", "id")
+
+	cmd.RegisterFlagCompletionFunc("path", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return completePathFlag(cmd.Context(), toComplete, true)
+	})
+}
+
+// addAttachmentFlags adds the --attachment-id and --name flags that select an attachment
+func addAttachmentFlags(cmd *cobra.Command) {
+	cmd.Flags().String("attachment-id", "", "Id of attachment")
+	cmd.Flags().StringP("name", "n", "", "File name of attachment")
+	cmd.MarkFlagsMutuallyExclusive("attachment-id", "name")
+	cmd.MarkFlagsOneRequired("attachment-id", "name")
+}
+
+// entryIdFromFlags returns the id of the entry selected with --path or --id
+func entryIdFromFlags(cmd *cobra.Command, client *pleasant.Client) (string, error) {
+	if cmd.Flags().Changed("path") {
+		resourcePath, err := cmd.Flags().GetString("path")
+		if err != nil {
+			return "", err
+		}
+
+		return client.GetIdByResourcePath(cmd.Context(), resourcePath, "entry")
+	}
+
+	return cmd.Flags().GetString("id")
+}
+
+// attachmentFromFlags returns the attachment selected with --attachment-id or --name, including its contents
+func attachmentFromFlags(ctx context.Context, cmd *cobra.Command, client *pleasant.Client, entryId string) (*pleasant.Attachment, error) {
+	if cmd.Flags().Changed("attachment-id") {
+		attachmentId, err := cmd.Flags().GetString("attachment-id")
+		if err != nil {
+			return nil, err
+		}
+
+		return client.GetEntryAttachment(ctx, entryId, attachmentId)
+	}
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := client.GetEntryAttachments(ctx, entryId)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []pleasant.Attachment
+	for _, a := range attachments {
+		if a.FileName == name {
+			found = append(found, a)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("%w: %v", pleasant.ErrNotFound, name)
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, fmt.Errorf("%w: %v, use --attachment-id", pleasant.ErrAmbiguousResult, name)
+	}
+}
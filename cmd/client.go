@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+// newTokenStore returns the store for the token file
+// The token is encrypted if PLEASANT_TOKEN_PASSPHRASE is set or a token key file is configured
+func newTokenStore() pleasant.TokenStore {
+	if passphrase := os.Getenv("PLEASANT_TOKEN_PASSPHRASE"); passphrase != "" {
+		return pleasant.NewPassphraseTokenStore(tokenFile, passphrase)
+	}
+
+	if keyFile := viper.GetString("tokenkeyfile"); keyFile != "" {
+		return pleasant.NewKeyFileTokenStore(tokenFile, keyFile)
+	}
+
+	return pleasant.NewFileTokenStore(tokenFile)
+}
+
+// isServerUrlSet checks whether a server URL is configured
+func isServerUrlSet() *pleasant.Prerequisite {
+	return &pleasant.Prerequisite{
+		Message:         "Server URL is not set. Please set it with 'pleasant-cli config serverurl <SERVER URL>'.",
+		PrerequisiteMet: viper.GetString("serverurl") != "",
+	}
+}
+
+// newClient returns a Pleasant client configured from the loaded config and token store
+// If an index TTL is configured, paths are resolved with the local path index
+// When the token is refreshed, the new token is saved to the token store
+// If credentials are set in the environment, the client logs in with those instead and nothing is saved
+func newClient() *pleasant.Client {
+	// Errors loading the token are reported by the IsTokenValid prerequisite
+	token, _ := tokenStore.Load()
+	if token == nil {
+		token = &pleasant.Token{}
+	}
+
+	auth := pleasant.WithRefreshableToken(token, tokenStore.Save)
+	if username, password, ok := pleasant.EnvCredentials(); ok {
+		auth = pleasant.WithCredentials(username, password)
+	}
+
+	opts := []pleasant.Option{
+		auth,
+		pleasant.WithWarningHandler(func(err error) {
+			fmt.Fprintln(os.Stderr, "warning:", err)
+		}),
+		pleasant.WithTimeout(time.Duration(viper.GetInt("timeout")) * time.Second),
+		pleasant.WithRetryPolicy(pleasant.RetryPolicy{
+			MaxRetries: viper.GetInt("retries"),
+		}),
+	}
+
+	// An invalid resolver in the config file falls back to the default resolver
+	if resolver, err := pleasant.ParsePathResolver(viper.GetString("resolver")); err == nil {
+		opts = append(opts, pleasant.WithPathResolver(resolver))
+	}
+
+	// The path index is disabled unless a TTL is configured
+	if ttl := viper.GetInt("indexttl"); ttl > 0 && indexFile != "" {
+		opts = append(opts, pleasant.WithPathIndex(indexFile, time.Duration(ttl)*time.Second))
+	}
+
+	return pleasant.NewClient(viper.GetString("serverurl"), opts...)
+}
+
+// completePathFlag returns the completions for a --path flag
+// If completeAll is set, entries are completed alongside folders
+func completePathFlag(ctx context.Context, toComplete string, completeAll bool) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if toComplete == "" || strings.HasPrefix("Root", toComplete) {
+		return []cobra.Completion{
+			cobra.CompletionWithDesc("Root/", "folder"),
+		}, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+	}
+
+	if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
+		pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
+	}
+
+	client := newClient()
+
+	ePaths, fPaths, err := client.GetValidPaths(ctx, toComplete, completeAll)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	completions := make([]cobra.Completion, 0)
+	for _, fp := range fPaths {
+		completions = append(completions, cobra.CompletionWithDesc(fp, "folder"))
+	}
+	for _, ep := range ePaths {
+		completions = append(completions, cobra.CompletionWithDesc(ep, "entry"))
+	}
+
+	if len(completions) < 1 {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	// There are only entry completions, so we complete with a space
+	if len(fPaths) < 1 {
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return completions, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
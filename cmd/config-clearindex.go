@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/marevers/pleasant-cli/pleasant"
+	"github.com/spf13/cobra"
+)
+
+// clearIndexCmd represents the clearindex command
+var clearIndexCmd = &cobra.Command{
+	Use:   "clearindex",
+	Short: "Removes the local path index",
+	Long: `Removes the local path index, it is rebuilt on the next lookup if the index is enabled
+
+Example:
+pleasant-cli config clearindex`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := pleasant.ClearPathIndex(indexFile)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		pleasant.Exit("Index file deleted:", indexFile)
+	},
+}
+
+func init() {
+	configCmd.AddCommand(clearIndexCmd)
+}
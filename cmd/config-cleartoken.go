@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"os"
-
 	"github.com/marevers/pleasant-cli/pleasant"
 	"github.com/spf13/cobra"
 )
@@ -17,12 +15,12 @@ Example:
 pleasant-cli config cleartoken
 pleasant-cli config cleartoken --token <PATH>`,
 	Run: func(cmd *cobra.Command, args []string) {
-		err := os.Remove(tokenFile)
+		err := tokenStore.Clear()
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
 
-		pleasant.Exit("Token file deleted:", tokenFile)
+		pleasant.Exit("Token file deleted:", tokenStore.Location())
 	},
 }
 
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/marevers/pleasant-cli/pleasant"
+	"github.com/spf13/cobra"
+)
+
+// indexTtlCmd represents the indexttl command
+var indexTtlCmd = &cobra.Command{
+	Use:   "indexttl",
+	Short: "Sets the time to live of the local path index for pleasant-cli",
+	Long: `Sets the time to live of the local path index for pleasant-cli
+The path index maps the paths of all folders and entries to their ids, so --path flags and completion
+do not need to search the server for every lookup. It is built from the folder tree, stored in the
+user's cache directory and rebuilt once it is older than the time to live. Creating, modifying or
+deleting entries and folders with pleasant-cli removes the index, so it is rebuilt on the next lookup.
+Paths that are not found in the index are still searched for on the server.
+
+It is specified as seconds and the default value (when it is unconfigured / set to 0 in the config file) is 0,
+which disables the index.
+
+If a profile is in use, the value is saved to that profile.
+
+Example:
+pleasant-cli config indexttl 600`,
+	Args: cobra.MatchAll(cobra.MinimumNArgs(1)),
+	Run: func(cmd *cobra.Command, args []string) {
+		target, err := writeConfig("IndexTtl", args[0])
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		pleasant.Exit("Index TTL saved to:", target)
+	},
+}
+
+func init() {
+	configCmd.AddCommand(indexTtlCmd)
+}
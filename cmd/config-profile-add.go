@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"github.com/marevers/pleasant-cli/pleasant"
+	"github.com/spf13/cobra"
+)
+
+// profileAddCmd represents the profile add command
+var profileAddCmd = &cobra.Command{
+	Use:   "add <NAME>",
+	Short: "Adds a profile",
+	Long: `Adds a profile with its own server URL, timeout, retries and token file.
+If no token file is specified, $HOME/.pleasant-token-<NAME>.yaml is used.
+
+Examples:
+pleasant-cli config profile add staging --serverurl <SERVER URL>
+pleasant-cli config profile add production --serverurl <SERVER URL> --timeout 30 --retries 3`,
+	Args: cobra.MatchAll(cobra.ExactArgs(1)),
+	Run: func(cmd *cobra.Command, args []string) {
+		serverUrl, err := cmd.Flags().GetString("serverurl")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		timeout, err := cmd.Flags().GetInt("timeout")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		retries, err := cmd.Flags().GetInt("retries")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		tf, err := cmd.Flags().GetString("tokenfile")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		p := &pleasant.Profile{
+			ServerUrl: serverUrl,
+			Timeout:   timeout,
+			Retries:   retries,
+			TokenFile: tf,
+		}
+
+		err = pleasant.AddProfile(cfgFile, args[0], p)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		pleasant.Exit("Profile", args[0], "saved to:", cfgFile)
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(profileAddCmd)
+
+	profileAddCmd.Flags().String("serverurl", "", "Server URL of the profile")
+	profileAddCmd.Flags().Int("timeout", 0, "Timeout in seconds of the profile")
+	profileAddCmd.Flags().Int("retries", 0, "Number of retries on transient server errors of the profile")
+	profileAddCmd.Flags().String("tokenfile", "", "Token file of the profile (default is $HOME/.pleasant-token-<NAME>.yaml)")
+	profileAddCmd.MarkFlagRequired("serverurl")
+}
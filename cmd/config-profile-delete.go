@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/marevers/pleasant-cli/pleasant"
+	"github.com/spf13/cobra"
+)
+
+// profileDeleteCmd represents the profile delete command
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete <NAME>",
+	Short: "Deletes a profile",
+	Long: `Deletes a profile from the config file. Its token file is not removed,
+use 'pleasant-cli config cleartoken --profile <NAME>' beforehand to do so.
+
+Example:
+pleasant-cli config profile delete staging`,
+	Args: cobra.MatchAll(cobra.ExactArgs(1)),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return completeProfile(args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := pleasant.DeleteProfile(cfgFile, args[0])
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		pleasant.Exit("Profile", args[0], "deleted from:", cfgFile)
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(profileDeleteCmd)
+}
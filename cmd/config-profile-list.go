@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+	"github.com/spf13/cobra"
+)
+
+// profileListCmd represents the profile list command
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists all profiles",
+	Long: `Lists all profiles with their server URL. The current profile is marked with '*'.
+
+Example:
+pleasant-cli config profile list`,
+	Run: func(cmd *cobra.Command, args []string) {
+		names, current, err := pleasant.ListProfiles(cfgFile)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		for _, name := range names {
+			p, err := pleasant.LoadProfile(cfgFile, name)
+			if err != nil {
+				pleasant.ExitFatal(err)
+			}
+
+			marker := " "
+			if name == current {
+				marker = "*"
+			}
+
+			fmt.Printf("%v %v\t%v\n", marker, name, p.ServerUrl)
+		}
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(profileListCmd)
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/marevers/pleasant-cli/pleasant"
+	"github.com/spf13/cobra"
+)
+
+// profileUseCmd represents the profile use command
+var profileUseCmd = &cobra.Command{
+	Use:   "use <NAME>",
+	Short: "Sets the current profile",
+	Long: `Sets the current profile, which is used when neither --profile nor PLEASANT_PROFILE is set.
+To stop using a profile and return to the default configuration, use --none.
+
+Examples:
+pleasant-cli config profile use staging
+pleasant-cli config profile use --none`,
+	Args: cobra.MatchAll(cobra.MaximumNArgs(1)),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return completeProfile(args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		var name string
+
+		if !cmd.Flags().Changed("none") {
+			if len(args) < 1 {
+				pleasant.ExitFatal(pleasant.ErrProfileNameRequired)
+			}
+
+			name = args[0]
+		}
+
+		err := pleasant.UseProfile(cfgFile, name)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		if name == "" {
+			pleasant.Exit("No profile in use")
+		}
+
+		pleasant.Exit("Using profile", name)
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(profileUseCmd)
+
+	profileUseCmd.Flags().Bool("none", false, "Stop using a profile")
+}
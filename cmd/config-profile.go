@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/marevers/pleasant-cli/pleasant"
+	"github.com/spf13/cobra"
+)
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manages profiles for multiple Pleasant Password servers",
+	Long: `Manages profiles for multiple Pleasant Password servers.
+Each profile has its own server URL, timeout, retries and token file.
+
+The profile is selected with --profile, the PLEASANT_PROFILE environment variable
+or the current profile set with 'pleasant-cli config profile use', in that order.`,
+	Args: cobra.MatchAll(cobra.MinimumNArgs(1)),
+	Run: func(cmd *cobra.Command, args []string) {
+	},
+}
+
+func init() {
+	configCmd.AddCommand(profileCmd)
+}
+
+// completeProfile completes the name of a profile as the first argument
+func completeProfile(args []string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	names, _, err := pleasant.ListProfiles(cfgFile)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
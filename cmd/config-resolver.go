@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"github.com/marevers/pleasant-cli/pleasant"
+	"github.com/spf13/cobra"
+)
+
+// resolverCmd represents the resolver command
+var resolverCmd = &cobra.Command{
+	Use:   "resolver",
+	Short: "Sets how pleasant-cli resolves paths to entries and folders",
+	Long: `Sets how pleasant-cli resolves paths to entries and folders
+Two resolvers are available:
+
+search: searches for the last component of the path and filters the results by their full path.
+        This needs a single request, but depends on the search functionality of the server.
+tree:   walks the folder tree from the root folder down, one request per path component.
+        This resolves every path that is unambiguous, even if folders with the same name exist elsewhere.
+
+The default value (when it is unconfigured in the config file) is 'search'.
+If a path matches more than one entry or folder, the ids of all candidates are listed in the error.
+
+If a profile is in use, the value is saved to that profile.
+
+Example:
+pleasant-cli config resolver tree`,
+	Args:      cobra.MatchAll(cobra.ExactArgs(1)),
+	ValidArgs: []cobra.Completion{string(pleasant.ResolverSearch), string(pleasant.ResolverTree)},
+	Run: func(cmd *cobra.Command, args []string) {
+		_, err := pleasant.ParsePathResolver(args[0])
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		target, err := writeConfig("Resolver", args[0])
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		pleasant.Exit("Resolver saved to:", target)
+	},
+}
+
+func init() {
+	configCmd.AddCommand(resolverCmd)
+}
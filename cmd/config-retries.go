@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/marevers/pleasant-cli/pleasant"
+	"github.com/spf13/cobra"
+)
+
+// retriesCmd represents the retries command
+var retriesCmd = &cobra.Command{
+	Use:   "retries",
+	Short: "Sets the number of retries on transient server errors for pleasant-cli",
+	Long: `Sets the number of retries on transient server errors for pleasant-cli
+Read-only requests (including search) and partial updates are retried when the server responds
+with 429, 502, 503 or 504 or when the connection fails. Retries use exponential backoff with jitter
+and honor the Retry-After header sent by the server.
+
+The default value (when it is unconfigured / set to 0 in the config file) is 0, which disables retries.
+
+If a profile is in use, the value is saved to that profile.
+
+Example:
+pleasant-cli config retries 3`,
+	Args: cobra.MatchAll(cobra.MinimumNArgs(1)),
+	Run: func(cmd *cobra.Command, args []string) {
+		target, err := writeConfig("Retries", args[0])
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		pleasant.Exit("Retries saved to:", target)
+	},
+}
+
+func init() {
+	configCmd.AddCommand(retriesCmd)
+}
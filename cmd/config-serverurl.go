@@ -14,16 +14,18 @@ It must be specified as <PROTOCOL>://<URL>(:<PORT>).
 
 If the port is either 80 or 443, it can be inferred from the protocol and can be omitted.
 
+If a profile is in use, the value is saved to that profile.
+
 Example:
 pleasant-cli config serverurl <SERVER URL>`,
 	Args: cobra.MatchAll(cobra.MinimumNArgs(1)),
 	Run: func(cmd *cobra.Command, args []string) {
-		err := pleasant.WriteConfigFile(cfgFile, "ServerUrl", args[0])
+		target, err := writeConfig("ServerUrl", args[0])
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
 
-		pleasant.Exit("Server URL saved to:", cfgFile)
+		pleasant.Exit("Server URL saved to:", target)
 	},
 }
 
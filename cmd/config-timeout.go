@@ -12,16 +12,18 @@ var timeoutCmd = &cobra.Command{
 	Long: `Sets the Pleasant Password server timeout for pleasant-cli
 It is specified as seconds and the default value (when it is unconfigured / set to 0 in the config file) is 20 seconds.
 
+If a profile is in use, the value is saved to that profile.
+
 Example:
 pleasant-cli config timeout 30`,
 	Args: cobra.MatchAll(cobra.MinimumNArgs(1)),
 	Run: func(cmd *cobra.Command, args []string) {
-		err := pleasant.WriteConfigFile(cfgFile, "Timeout", args[0])
+		target, err := writeConfig("Timeout", args[0])
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
 
-		pleasant.Exit("Timeout saved to:", cfgFile)
+		pleasant.Exit("Timeout saved to:", target)
 	},
 }
 
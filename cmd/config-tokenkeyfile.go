@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/marevers/pleasant-cli/pleasant"
+	"github.com/spf13/cobra"
+)
+
+// tokenKeyFileCmd represents the tokenkeyfile command
+var tokenKeyFileCmd = &cobra.Command{
+	Use:   "tokenkeyfile",
+	Short: "Sets the key file used to encrypt the token file for pleasant-cli",
+	Long: `Sets the key file used to encrypt the token file for pleasant-cli
+When set, the token file is encrypted at rest with a key derived from the contents of the key file.
+The key file must only be accessible by its owner (e.g. mode 600) and should contain at least 32 random bytes,
+for example generated with 'head -c 32 /dev/urandom > ~/.pleasant-token.key'.
+
+Alternatively, the token file is encrypted with a passphrase when PLEASANT_TOKEN_PASSPHRASE is set.
+The passphrase takes precedence over the key file.
+
+An existing plaintext token file is encrypted the next time the token is saved, e.g. on login.
+To stop using a key file, set it to an empty string and log in again.
+
+If a profile is in use, the value is saved to that profile.
+
+Example:
+pleasant-cli config tokenkeyfile ~/.pleasant-token.key`,
+	Args: cobra.MatchAll(cobra.ExactArgs(1)),
+	Run: func(cmd *cobra.Command, args []string) {
+		target, err := writeConfig("TokenKeyFile", args[0])
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		pleasant.Exit("Token key file saved to:", target)
+	},
+}
+
+func init() {
+	configCmd.AddCommand(tokenKeyFileCmd)
+}
@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
+
+	"github.com/marevers/pleasant-cli/pleasant"
 )
 
 // configCmd represents the config command
@@ -17,3 +21,13 @@ var configCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(configCmd)
 }
+
+// writeConfig sets key to value in the active profile or, if no profile is in use, in the config file
+// It returns a description of where the value was saved
+func writeConfig(key, value string) (string, error) {
+	if profile != "" {
+		return fmt.Sprintf("%v (profile %v)", cfgFile, profile), pleasant.WriteProfileConfigFile(cfgFile, profile, key, value)
+	}
+
+	return cfgFile, pleasant.WriteConfigFile(cfgFile, key, value)
+}
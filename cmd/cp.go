@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+// cpCmd represents the cp command
+var cpCmd = &cobra.Command{
+	Use:   "cp SRC... DST",
+	Short: "Copies entries and folders",
+	Long: `Copies entries and folders, like cp does for files.
+Sources and destination can be paths or ids. A path must be absolute and starts with 'Root/',
+e.g. 'Root/Folder1/Entry'. A path that matches both a folder and an entry refers to the folder.
+
+Entries are copied with their username, password, URL, notes, tags, expiry date and custom fields.
+To copy their attachments as well, use --attachments.
+To copy folders with all their subfolders and entries, use -r.
+
+If the destination is an existing folder, the sources are copied into it and keep their names.
+Otherwise the source is copied to the parent folder of the destination with the last component
+of the destination path as its name. Multiple sources can only be copied into an existing folder.
+If an entry or folder with the same name already exists at the destination, nothing is copied.
+
+Every entry and folder that is created is reported with its path and id.
+
+Examples:
+pleasant-cli cp Root/Folder1/Entry Root/Folder2
+pleasant-cli cp Root/Folder1/Entry Root/Folder1/EntryCopy
+pleasant-cli cp -r Root/Templates/Customer Root/Customers/NewCustomer
+pleasant-cli cp -r --attachments Root/Folder1 Root/Folder2 Root/Backup`,
+	Args: cobra.MatchAll(cobra.MinimumNArgs(2)),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return completePathFlag(cmd.Context(), toComplete, true)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
+			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
+		}
+
+		recursive, err := cmd.Flags().GetBool("recursive")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		attachments, err := cmd.Flags().GetBool("attachments")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		client := newClient()
+
+		sources := args[:len(args)-1]
+
+		dst, err := resolveDestination(cmd.Context(), client, args[len(args)-1], len(sources) > 1)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		c := &copier{
+			client:      client,
+			w:           os.Stdout,
+			attachments: attachments,
+		}
+
+		for _, src := range sources {
+			id, resourceType, err := client.ResolveResource(cmd.Context(), src)
+			if err != nil {
+				pleasant.ExitFatal(fmt.Errorf("%w: %v", err, src))
+			}
+
+			if resourceType == "folder" && !recursive {
+				pleasant.ExitFatal(fmt.Errorf("%w: %v", pleasant.ErrRecursiveRequired, src))
+			}
+
+			err = c.copy(cmd.Context(), id, resourceType, dst)
+			if err != nil {
+				pleasant.ExitFatal(err)
+			}
+		}
+
+		pleasant.Exit(fmt.Sprintf("Copied %v and %v", plural(c.folders, "folder", "folders"), plural(c.entries, "entry", "entries")))
+	},
+}
+
+// copier copies entries and folders for the cp command and reports what it created
+type copier struct {
+	client      *pleasant.Client
+	w           io.Writer
+	attachments bool
+
+	// Number of folders and entries created
+	folders int
+	entries int
+}
+
+// copy copies the entry or folder with the given id to dst
+func (c *copier) copy(ctx context.Context, id, resourceType string, dst *destination) error {
+	var name string
+	var entry *pleasant.Entry
+	var tree *pleasant.Folder
+
+	if resourceType == "entry" {
+		e, err := c.client.GetEntry(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		name, entry = e.Name, e
+	} else {
+		f, err := c.client.GetFolderTree(ctx, id, 0)
+		if err != nil {
+			return err
+		}
+
+		name, tree = f.Name, f
+	}
+
+	newName := name
+	if dst.Name != "" {
+		newName = dst.Name
+	}
+
+	target := dst.target(name)
+
+	existingId, err := existingChildId(ctx, c.client, dst.FolderId, newName, resourceType)
+	if err != nil {
+		return err
+	}
+
+	if existingId != "" {
+		return fmt.Errorf("%w: %v", pleasant.ErrDestinationExists, target)
+	}
+
+	if resourceType == "entry" {
+		return c.copyEntry(ctx, entry, dst.FolderId, newName, target)
+	}
+
+	return c.copyFolder(ctx, tree, dst.FolderId, newName, target)
+}
+
+// copyEntry creates a copy of entry e, including its password, in the folder with parentId
+// The password is not part of e and is fetched separately
+func (c *copier) copyEntry(ctx context.Context, e *pleasant.Entry, parentId, name, path string) error {
+	password, err := c.client.GetEntryPassword(ctx, e.Id)
+	if err != nil {
+		return err
+	}
+
+	entry := &pleasant.Entry{
+		CustomUserFields:        e.CustomUserFields,
+		CustomApplicationFields: e.CustomApplicationFields,
+		Tags:                    e.Tags,
+		Name:                    name,
+		Username:                e.Username,
+		Password:                password,
+		Url:                     e.Url,
+		Notes:                   e.Notes,
+		GroupId:                 parentId,
+		Expires:                 e.Expires,
+	}
+
+	newId, err := c.client.CreateEntry(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	c.entries++
+	fmt.Fprintln(c.w, "Created entry", path, newId)
+
+	if !c.attachments {
+		return nil
+	}
+
+	attachments, err := c.client.GetEntryAttachments(ctx, e.Id)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range attachments {
+		_, err := c.client.CreateEntryAttachment(ctx, newId, &a)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(c.w, "Created attachment", a.FileName, "of", path)
+	}
+
+	return nil
+}
+
+// copyFolder creates a copy of folder with all its subfolders and entries in the folder with parentId
+func (c *copier) copyFolder(ctx context.Context, folder *pleasant.Folder, parentId, name, path string) error {
+	f := &pleasant.Folder{
+		CustomUserFields:        folder.CustomUserFields,
+		CustomApplicationFields: folder.CustomApplicationFields,
+		Tags:                    folder.Tags,
+		Name:                    name,
+		ParentId:                parentId,
+		Notes:                   folder.Notes,
+		Expires:                 folder.Expires,
+	}
+
+	newId, err := c.client.CreateFolder(ctx, f)
+	if err != nil {
+		return err
+	}
+
+	c.folders++
+	fmt.Fprintln(c.w, "Created folder", path, newId)
+
+	// The entries in the folder tree are complete apart from their passwords
+	for i := range folder.Credentials {
+		e := &folder.Credentials[i]
+
+		err := c.copyEntry(ctx, e, newId, e.Name, path+"/"+pleasant.EscapePathSegment(e.Name))
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range folder.Children {
+		child := &folder.Children[i]
+
+		err := c.copyFolder(ctx, child, newId, child.Name, path+"/"+pleasant.EscapePathSegment(child.Name))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(cpCmd)
+
+	cpCmd.Flags().BoolP("recursive", "r", false, "Copy folders with all their subfolders and entries")
+	cpCmd.Flags().Bool("attachments", false, "Copy the attachments of entries")
+}
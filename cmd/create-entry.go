@@ -9,7 +9,9 @@ import (
 var createEntryCmd = &cobra.Command{
 	Use:   "entry",
 	Short: "Creates an entry",
-	Long: `Creates an entry in Pleasant Password Server. Takes a JSON string as input.
+	Long: `Creates an entry in Pleasant Password Server. Takes JSON as input.
+The JSON can be passed as a string with --data, read from a file with '--data @FILE' or from stdin with '--data -'.
+With --file, the input is read from a YAML or JSON file, use '--file -' for stdin.
 Returns its id if succesful.
 'GroupId' can be omitted if the path of the entry is supplied.
 
@@ -39,15 +41,18 @@ pleasant-cli create entry --path 'Root/Folder1/TestEntry' --data '
     "Url": "",
     "Notes": "",
     "Expires": null
-}'`,
+}'
+
+pleasant-cli create entry --path 'Root/Folder1/TestEntry' --file entry.yaml
+pleasant-cli create entry --path 'Root/Folder1/TestEntry' --data @entry.json`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
+		client := newClient()
 
-		json, err := cmd.Flags().GetString("data")
+		json, err := readData(cmd)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
@@ -63,13 +68,13 @@ pleasant-cli create entry --path 'Root/Folder1/TestEntry' --data '
 				pleasant.ExitFatal(err)
 			}
 
-			pid, err := pleasant.GetParentIdByResourcePath(baseUrl, resourcePath, bearerToken)
+			pid, err := client.GetParentIdByResourcePath(cmd.Context(), resourcePath)
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
 
 			if !pleasant.PathAndNameMatching(resourcePath, input.Name) {
-				pleasant.ExitFatal("error: entry name from path and data do not match")
+				pleasant.ExitFatal(pleasant.ErrNameMismatch)
 			}
 
 			input.GroupId = pid
@@ -83,7 +88,7 @@ pleasant-cli create entry --path 'Root/Folder1/TestEntry' --data '
 		}
 
 		if cmd.Flags().Changed("no-duplicates") {
-			exists, err := pleasant.DuplicateEntryExists(baseUrl, json, bearerToken)
+			exists, err := client.DuplicateEntryExists(cmd.Context(), json)
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
@@ -93,7 +98,7 @@ pleasant-cli create entry --path 'Root/Folder1/TestEntry' --data '
 			}
 		}
 
-		id, err := pleasant.PostJsonString(baseUrl, pleasant.PathEntry, json, bearerToken)
+		id, err := client.PostJsonString(cmd.Context(), pleasant.PathEntry, json)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
@@ -105,12 +110,11 @@ pleasant-cli create entry --path 'Root/Folder1/TestEntry' --data '
 func init() {
 	createCmd.AddCommand(createEntryCmd)
 
-	createEntryCmd.Flags().StringP("data", "d", "", "JSON string with entry data")
+	addDataFlags(createEntryCmd, "JSON string with entry data")
 	createEntryCmd.Flags().StringP("path", "p", "", "Path to entry")
-	createEntryCmd.MarkFlagRequired("data")
 
 	createEntryCmd.RegisterFlagCompletionFunc("path", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
-		return pleasant.CompletePathFlag(toComplete, false)
+		return completePathFlag(cmd.Context(), toComplete, false)
 	})
 
 	createEntryCmd.Flags().Bool("no-duplicates", false, "Avoid creating duplicate entries")
@@ -9,7 +9,9 @@ import (
 var createFolderCmd = &cobra.Command{
 	Use:   "folder",
 	Short: "Creates a folder",
-	Long: `Creates a folder in Pleasant Password Server. Takes a JSON string as input.
+	Long: `Creates a folder in Pleasant Password Server. Takes JSON as input.
+The JSON can be passed as a string with --data, read from a file with '--data @FILE' or from stdin with '--data -'.
+With --file, the input is read from a YAML or JSON file, use '--file -' for stdin.
 Returns its id if succesful.
 'ParentId' can be omitted if the path of the folder is supplied.
 
@@ -37,15 +39,17 @@ pleasant-cli create folder --path 'Root/Folder1/TestFolder' --data '
     "Name": "TestFolder",
     "Notes": null,
     "Expires": null
-}'`,
+}'
+
+pleasant-cli create folder --path 'Root/Folder1/TestFolder' --file folder.yaml`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
+		client := newClient()
 
-		json, err := cmd.Flags().GetString("data")
+		json, err := readData(cmd)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
@@ -61,13 +65,13 @@ pleasant-cli create folder --path 'Root/Folder1/TestFolder' --data '
 				pleasant.ExitFatal(err)
 			}
 
-			pid, err := pleasant.GetParentIdByResourcePath(baseUrl, resourcePath, bearerToken)
+			pid, err := client.GetParentIdByResourcePath(cmd.Context(), resourcePath)
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
 
 			if !pleasant.PathAndNameMatching(resourcePath, input.Name) {
-				pleasant.ExitFatal("error: folder name from path and data do not match")
+				pleasant.ExitFatal(pleasant.ErrNameMismatch)
 			}
 
 			input.ParentId = pid
@@ -81,7 +85,7 @@ pleasant-cli create folder --path 'Root/Folder1/TestFolder' --data '
 		}
 
 		if cmd.Flags().Changed("no-duplicates") {
-			exists, err := pleasant.DuplicateFolderExists(baseUrl, json, bearerToken)
+			exists, err := client.DuplicateFolderExists(cmd.Context(), json)
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
@@ -91,7 +95,7 @@ pleasant-cli create folder --path 'Root/Folder1/TestFolder' --data '
 			}
 		}
 
-		id, err := pleasant.PostJsonString(baseUrl, pleasant.PathFolders, json, bearerToken)
+		id, err := client.PostJsonString(cmd.Context(), pleasant.PathFolders, json)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
@@ -103,12 +107,11 @@ pleasant-cli create folder --path 'Root/Folder1/TestFolder' --data '
 func init() {
 	createCmd.AddCommand(createFolderCmd)
 
-	createFolderCmd.Flags().StringP("data", "d", "", "JSON string with folder data")
+	addDataFlags(createFolderCmd, "JSON string with folder data")
 	createFolderCmd.Flags().StringP("path", "p", "", "Path to folder")
-	createFolderCmd.MarkFlagRequired("data")
 
 	createFolderCmd.RegisterFlagCompletionFunc("path", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
-		return pleasant.CompletePathFlag(toComplete, false)
+		return completePathFlag(cmd.Context(), toComplete, false)
 	})
 
 	createFolderCmd.Flags().Bool("no-duplicates", false, "Avoid creating duplicate folders")
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+// addDataFlags adds the --data and --file flags that supply the JSON input of a command
+func addDataFlags(cmd *cobra.Command, usage string) {
+	cmd.Flags().StringP("data", "d", "", usage+", '@FILE' to read it from a file or '-' to read it from stdin")
+	cmd.Flags().StringP("file", "f", "", "YAML or JSON file with "+strings.TrimPrefix(usage, "JSON string with ")+", '-' for stdin")
+	cmd.MarkFlagsMutuallyExclusive("data", "file")
+	cmd.MarkFlagsOneRequired("data", "file")
+}
+
+// readData returns the JSON input supplied with --data or --file
+// --data takes a JSON string, '@FILE' or '-' for stdin, --file takes a YAML or JSON file or '-' for stdin
+func readData(cmd *cobra.Command) (string, error) {
+	if cmd.Flags().Changed("file") {
+		file, err := cmd.Flags().GetString("file")
+		if err != nil {
+			return "", err
+		}
+
+		b, err := readFileOrStdin(file)
+		if err != nil {
+			return "", err
+		}
+
+		return yamlToJson(b)
+	}
+
+	data, err := cmd.Flags().GetString("data")
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case data == "-":
+		b, err := readFileOrStdin(data)
+		if err != nil {
+			return "", err
+		}
+
+		data = string(b)
+	case strings.HasPrefix(data, "@"):
+		b, err := readFileOrStdin(strings.TrimPrefix(data, "@"))
+		if err != nil {
+			return "", err
+		}
+
+		data = string(b)
+	}
+
+	if strings.TrimSpace(data) == "" {
+		return "", pleasant.ErrNoData
+	}
+
+	return data, nil
+}
+
+func readFileOrStdin(file string) ([]byte, error) {
+	if file == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+
+	return os.ReadFile(file)
+}
+
+// yamlToJson converts a YAML document to JSON, JSON input is returned as is
+// All fields of the API are strings or booleans, so scalars other than null and booleans become strings.
+// This keeps e.g. '0123' or '2030-01-01' as written instead of converting them to numbers or timestamps
+func yamlToJson(b []byte) (string, error) {
+	if json.Valid(b) {
+		return string(b), nil
+	}
+
+	var doc yaml.Node
+
+	err := yaml.Unmarshal(b, &doc)
+	if err != nil {
+		return "", err
+	}
+
+	if len(doc.Content) == 0 {
+		return "", pleasant.ErrNoData
+	}
+
+	v, err := yamlValue(&doc)
+	if err != nil {
+		return "", err
+	}
+
+	j, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(j), nil
+}
+
+// yamlValue returns the value of a YAML node as a type that encodes to JSON
+func yamlValue(n *yaml.Node) (any, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		return yamlValue(n.Content[0])
+	case yaml.AliasNode:
+		return yamlValue(n.Alias)
+	case yaml.MappingNode:
+		m := make(map[string]any, len(n.Content)/2)
+
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v, err := yamlValue(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+
+			m[n.Content[i].Value] = v
+		}
+
+		return m, nil
+	case yaml.SequenceNode:
+		l := make([]any, 0, len(n.Content))
+
+		for _, c := range n.Content {
+			v, err := yamlValue(c)
+			if err != nil {
+				return nil, err
+			}
+
+			l = append(l, v)
+		}
+
+		return l, nil
+	}
+
+	switch n.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var b bool
+		err := n.Decode(&b)
+		return b, err
+	default:
+		return n.Value, nil
+	}
+}
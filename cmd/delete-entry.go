@@ -24,11 +24,11 @@ pleasant-cli delete entry --path <path>
 pleasant-cli delete entry --id <id> --delete
 pleasant-cli delete entry --id <id> --delete --useraccess <accessrowid>`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
+		client := newClient()
 
 		var identifier string
 
@@ -38,7 +38,7 @@ pleasant-cli delete entry --id <id> --delete --useraccess <accessrowid>`,
 				pleasant.ExitFatal(err)
 			}
 
-			id, err := pleasant.GetIdByResourcePath(baseUrl, resourcePath, "entry", bearerToken)
+			id, err := client.GetIdByResourcePath(cmd.Context(), resourcePath, "entry")
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
@@ -80,7 +80,7 @@ pleasant-cli delete entry --id <id> --delete --useraccess <accessrowid>`,
 			msg = fmt.Sprintf("Entry with id %v archived/deleted", identifier)
 		}
 
-		_, err := pleasant.DeleteJsonString(baseUrl, subPath, json, bearerToken)
+		_, err := client.DeleteJsonString(cmd.Context(), subPath, json)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
@@ -98,7 +98,7 @@ func init() {
 	deleteEntryCmd.MarkFlagsOneRequired("path", "id")
 
 	deleteEntryCmd.RegisterFlagCompletionFunc("path", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
-		return pleasant.CompletePathFlag(toComplete, true)
+		return completePathFlag(cmd.Context(), toComplete, true)
 	})
 
 	deleteEntryCmd.Flags().String("useraccess", "", "Archives/deletes the user access assignment with this id")
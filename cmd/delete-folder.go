@@ -25,11 +25,11 @@ pleasant-cli delete folder --path <path>
 pleasant-cli delete folder --id <id> --delete
 pleasant-cli delete folder --id <id> --delete --useraccess <accessrowid>`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
+		client := newClient()
 
 		var identifier string
 
@@ -39,7 +39,7 @@ pleasant-cli delete folder --id <id> --delete --useraccess <accessrowid>`,
 				pleasant.ExitFatal(err)
 			}
 
-			id, err := pleasant.GetIdByResourcePath(baseUrl, resourcePath, "folder", bearerToken)
+			id, err := client.GetIdByResourcePath(cmd.Context(), resourcePath, "folder")
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
@@ -81,7 +81,7 @@ pleasant-cli delete folder --id <id> --delete --useraccess <accessrowid>`,
 			msg = fmt.Sprintf("Folder with id %v archived/deleted", identifier)
 		}
 
-		_, err := pleasant.DeleteJsonString(baseUrl, subPath, json, bearerToken)
+		_, err := client.DeleteJsonString(cmd.Context(), subPath, json)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
@@ -99,7 +99,7 @@ func init() {
 	deleteFolderCmd.MarkFlagsOneRequired("path", "id")
 
 	deleteFolderCmd.RegisterFlagCompletionFunc("path", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
-		return pleasant.CompletePathFlag(toComplete, false)
+		return completePathFlag(cmd.Context(), toComplete, false)
 	})
 
 	deleteFolderCmd.Flags().String("useraccess", "", "Archives/deletes the user access assignment with this id")
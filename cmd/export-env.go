@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+// exportEnvCmd represents the export env command
+var exportEnvCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Exports the entries of a folder as environment variables",
+	Long: `Exports the entries of a folder as environment variables, e.g. to create a .env file.
+A path must be absolute and starts with 'Root/', e.g. 'Root/Folder1/Folder2'.
+
+Every entry in the folder becomes a variable with the password of the entry as its value.
+Use --field to export another field, field names are the same as for 'get entry --field'.
+
+Variable names are created from the names of the entries, or from another field with --name-field,
+e.g. a custom field. Entries without that field are skipped. Characters other than letters, digits
+and underscores are replaced by an underscore and names are converted to upper case, use --case
+to keep the case or convert them to lower case. --prefix is prepended to every name.
+
+The variables are written as a .env file, as shell 'export' lines or as a JSON object,
+to stdout or to the file given with --out. An output file is only accessible by the current user.
+
+Examples:
+pleasant-cli export env --path Root/Apps/Billing > .env
+pleasant-cli export env --path Root/Apps/Billing --name-field CustomUserFields.EnvName --out .env
+pleasant-cli export env --path Root/Apps/Billing --prefix BILLING_ --format export
+pleasant-cli export env --id <id> --field Username --case keep --format json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
+			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
+		}
+
+		nameField, err := cmd.Flags().GetString("name-field")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		field, err := cmd.Flags().GetString("field")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		nameCase, err := cmd.Flags().GetString("case")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		prefix, err := cmd.Flags().GetString("prefix")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		out, err := cmd.Flags().GetString("out")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		c, err := pleasant.ParseEnvNameCase(nameCase)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		if !slices.Contains(exportEnvFormats, format) {
+			pleasant.ExitFatal(fmt.Errorf("%w, must be one of: %v", pleasant.ErrInvalidExportFormat, strings.Join(exportEnvFormats, ", ")))
+		}
+
+		client := newClient()
+
+		var id string
+
+		if cmd.Flags().Changed("path") {
+			resourcePath, err := cmd.Flags().GetString("path")
+			if err != nil {
+				pleasant.ExitFatal(err)
+			}
+
+			id, err = client.GetIdByResourcePath(cmd.Context(), resourcePath, "folder")
+			if err != nil {
+				pleasant.ExitFatal(err)
+			}
+		} else {
+			id, err = cmd.Flags().GetString("id")
+			if err != nil {
+				pleasant.ExitFatal(err)
+			}
+		}
+
+		// Only the entries of the folder itself are exported
+		folder, err := client.GetFolderTree(cmd.Context(), id, 1)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		vars := map[string]string{}
+
+		for i := range folder.Credentials {
+			e := &folder.Credentials[i]
+
+			name := e.Name
+
+			if nameField != "" {
+				name, err = pleasant.EntryField(e, nameField)
+				if err != nil && !errors.Is(err, pleasant.ErrFieldNotFound) {
+					pleasant.ExitFatal(err)
+				}
+
+				if name == "" {
+					fmt.Fprintf(os.Stderr, "Skipping entry %v, it has no %v\n", e.Name, nameField)
+					continue
+				}
+			}
+
+			name = pleasant.SanitizeEnvName(prefix+name, c)
+
+			if _, ok := vars[name]; ok {
+				pleasant.ExitFatal(fmt.Errorf("%w: %v", pleasant.ErrDuplicateVariable, name))
+			}
+
+			var value string
+
+			if pleasant.IsPasswordField(field) {
+				value, err = client.GetEntryPassword(cmd.Context(), e.Id)
+			} else {
+				value, err = pleasant.EntryField(e, field)
+			}
+
+			if err != nil {
+				pleasant.ExitFatal(fmt.Errorf("%w: %v", err, e.Name))
+			}
+
+			vars[name] = value
+		}
+
+		result, err := formatEnvVars(vars, format)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		if out == "-" {
+			_, err = os.Stdout.WriteString(result)
+			if err != nil {
+				pleasant.ExitFatal(err)
+			}
+
+			return
+		}
+
+		err = pleasant.WritePrivateFile(out, []byte(result))
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		pleasant.Exit(fmt.Sprintf("Exported %v to: %v", plural(len(vars), "variable", "variables"), out))
+	},
+}
+
+// exportEnvFormats are the values accepted by export env --format
+var exportEnvFormats = []string{"dotenv", "export", "json"}
+
+// formatEnvVars returns the variables sorted by name in the given format
+func formatEnvVars(vars map[string]string, format string) (string, error) {
+	if format == "json" {
+		b, err := json.MarshalIndent(vars, "", "  ")
+		if err != nil {
+			return "", err
+		}
+
+		return string(b) + "\n", nil
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	var sb strings.Builder
+
+	for _, name := range names {
+		if format == "export" {
+			fmt.Fprintf(&sb, "export %v=%v\n", name, pleasant.ShellQuote(vars[name]))
+		} else {
+			fmt.Fprintf(&sb, "%v=%v\n", name, pleasant.DotenvQuote(vars[name]))
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func init() {
+	exportCmd.AddCommand(exportEnvCmd)
+
+	exportEnvCmd.Flags().StringP("path", "p", "", "Path to folder")
+	exportEnvCmd.Flags().StringP("id", "i", "", "Id of folder")
+	exportEnvCmd.MarkFlagsMutuallyExclusive("path", "id")
+	exportEnvCmd.MarkFlagsOneRequired("path", "id")
+
+	exportEnvCmd.RegisterFlagCompletionFunc("path", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return completePathFlag(cmd.Context(), toComplete, false)
+	})
+
+	exportEnvCmd.Flags().String("field", pleasant.DefaultReferenceField, "Field of the entries to export")
+	exportEnvCmd.Flags().String("name-field", "", "Field of the entries to use as variable name, e.g. 'CustomUserFields.EnvName' (default is the name of the entry)")
+	exportEnvCmd.Flags().String("case", string(pleasant.EnvNameUpper), "Case of variable names, one of: "+strings.Join(pleasant.EnvNameCases, ", "))
+	exportEnvCmd.Flags().String("prefix", "", "Prefix for every variable name")
+	exportEnvCmd.Flags().String("format", "dotenv", "Output format, one of: "+strings.Join(exportEnvFormats, ", "))
+	exportEnvCmd.Flags().String("out", "-", "Output file, '-' for stdout")
+
+	exportEnvCmd.RegisterFlagCompletionFunc("field", cobra.FixedCompletions(entryFields, cobra.ShellCompDirectiveNoFileComp))
+	exportEnvCmd.RegisterFlagCompletionFunc("name-field", cobra.FixedCompletions(entryFields, cobra.ShellCompDirectiveNoFileComp))
+	exportEnvCmd.RegisterFlagCompletionFunc("case", cobra.FixedCompletions(pleasant.EnvNameCases, cobra.ShellCompDirectiveNoFileComp))
+	exportEnvCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(exportEnvFormats, cobra.ShellCompDirectiveNoFileComp))
+}
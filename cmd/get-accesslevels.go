@@ -14,27 +14,23 @@ var getAccesslevelsCmd = &cobra.Command{
 Example:
 pleasant-cli get accesslevels`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
-
-		accesslevels, err := pleasant.GetJsonBody(baseUrl, pleasant.PathAccessLevels, bearerToken)
+		format, err := outputFormat(cmd)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
 
-		if cmd.Flags().Changed("pretty") {
-			output, err := pleasant.PrettyPrintJson(accesslevels)
-			if err != nil {
-				pleasant.ExitFatal(err)
-			}
+		client := newClient()
 
-			pleasant.Exit(output)
+		accesslevels, err := client.GetJsonBody(cmd.Context(), pleasant.PathAccessLevels)
+		if err != nil {
+			pleasant.ExitFatal(err)
 		}
 
-		pleasant.Exit(accesslevels)
+		printOutput(format, accesslevels, nil)
 	},
 }
 
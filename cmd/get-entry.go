@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/marevers/pleasant-cli/pleasant"
 	"github.com/spf13/cobra"
 )
@@ -14,6 +17,9 @@ A path must be absolute and starts with 'Root/', e.g. 'Root/Folder1/Folder2/Entr
 
 To get the username of an entry, use --username.
 To get the password of an entry, use --password.
+To get other fields, use --field. It can be repeated and prints every value on its own line.
+Field names are case-insensitive, custom fields are selected with 'CustomUserFields.<name>'
+or 'CustomApplicationFields.<name>'. Tags are printed as a comma-separated list.
 
 To get the attachments of an entry, use --attachments.
 
@@ -21,13 +27,25 @@ Examples:
 pleasant-cli get entry --id <id>
 pleasant-cli get entry --path <path>
 pleasant-cli get entry --id <id> --username
+pleasant-cli get entry --path <path> --field Url --field Notes
+pleasant-cli get entry --path <path> --field Password --field CustomUserFields.ApiKey
 pleasant-cli get entry --path <path> --attachments`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
+		format, err := outputFormat(cmd)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		// Single values are printed as is, they have no output format
+		if format != nil && (cmd.Flags().Changed("field") || cmd.Flags().Changed("username") || cmd.Flags().Changed("password")) {
+			pleasant.ExitFatal(fmt.Errorf("%w: --output and --pretty cannot be combined with --field, --username or --password", pleasant.ErrInvalidOutput))
+		}
+
+		client := newClient()
 
 		var identifier string
 
@@ -37,7 +55,7 @@ pleasant-cli get entry --path <path> --attachments`,
 				pleasant.ExitFatal(err)
 			}
 
-			id, err := pleasant.GetIdByResourcePath(baseUrl, resourcePath, "entry", bearerToken)
+			id, err := client.GetIdByResourcePath(cmd.Context(), resourcePath, "entry")
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
@@ -52,6 +70,20 @@ pleasant-cli get entry --path <path> --attachments`,
 			identifier = id
 		}
 
+		if cmd.Flags().Changed("field") {
+			fields, err := cmd.Flags().GetStringArray("field")
+			if err != nil {
+				pleasant.ExitFatal(err)
+			}
+
+			values, err := client.GetEntryFields(cmd.Context(), identifier, fields)
+			if err != nil {
+				pleasant.ExitFatal(err)
+			}
+
+			pleasant.Exit(strings.Join(values, "\n"))
+		}
+
 		subPath := pleasant.PathEntry + "/" + identifier
 
 		switch {
@@ -63,19 +95,12 @@ pleasant-cli get entry --path <path> --attachments`,
 			subPath = subPath + "/useraccess"
 		}
 
-		entry, err := pleasant.GetJsonBody(baseUrl, subPath, bearerToken)
+		entry, err := client.GetJsonBody(cmd.Context(), subPath)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
 
 		switch {
-		case cmd.Flags().Changed("pretty"):
-			output, err := pleasant.PrettyPrintJson(entry)
-			if err != nil {
-				pleasant.ExitFatal(err)
-			}
-
-			pleasant.Exit(output)
 		case cmd.Flags().Changed("username"):
 			en, err := pleasant.UnmarshalEntry(entry)
 			if err != nil {
@@ -85,12 +110,19 @@ pleasant-cli get entry --path <path> --attachments`,
 			pleasant.Exit(en.Username)
 		case cmd.Flags().Changed("password"):
 			pleasant.Exit(pleasant.Unescape(pleasant.TrimDoubleQuotes(entry)))
+		case cmd.Flags().Changed("attachments"):
+			printOutput(format, entry, attachmentsTable)
+		case cmd.Flags().Changed("useraccess"):
+			printOutput(format, entry, nil)
 		default:
-			pleasant.Exit(entry)
+			printOutput(format, entry, entryTable)
 		}
 	},
 }
 
+// entryFields are the fields of an entry offered for completion of --field
+var entryFields = []string{"Id", "Name", "Username", "Password", "Url", "Notes", "GroupId", "Created", "Modified", "Expires", "HasOtpSecret", "Tags", "Attachments", "CustomUserFields.", "CustomApplicationFields."}
+
 func init() {
 	getCmd.AddCommand(getEntryCmd)
 
@@ -100,12 +132,15 @@ func init() {
 	getEntryCmd.MarkFlagsOneRequired("path", "id")
 
 	getEntryCmd.RegisterFlagCompletionFunc("path", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
-		return pleasant.CompletePathFlag(toComplete, true)
+		return completePathFlag(cmd.Context(), toComplete, true)
 	})
 
 	getEntryCmd.Flags().Bool("username", false, "Get the username of the entry")
 	getEntryCmd.Flags().Bool("password", false, "Get the password of the entry")
 	getEntryCmd.Flags().Bool("attachments", false, "Gets the attachments of the entry")
 	getEntryCmd.Flags().Bool("useraccess", false, "Gets the users that have access to the entry")
-	getEntryCmd.MarkFlagsMutuallyExclusive("username", "password", "attachments", "useraccess")
+	getEntryCmd.Flags().StringArray("field", []string{}, "Get a field of the entry, e.g. 'Url' or 'CustomUserFields.ApiKey' (can be repeated)")
+	getEntryCmd.MarkFlagsMutuallyExclusive("username", "password", "attachments", "useraccess", "field")
+
+	getEntryCmd.RegisterFlagCompletionFunc("field", cobra.FixedCompletions(entryFields, cobra.ShellCompDirectiveNoFileComp))
 }
@@ -16,11 +16,16 @@ Examples:
 pleasant-cli get folder --id <id>
 pleasant-cli get folder --path <path>`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
+		format, err := outputFormat(cmd)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		client := newClient()
 
 		var identifier string
 
@@ -30,7 +35,7 @@ pleasant-cli get folder --path <path>`,
 				pleasant.ExitFatal(err)
 			}
 
-			id, err := pleasant.GetIdByResourcePath(baseUrl, resourcePath, "folder", bearerToken)
+			id, err := client.GetIdByResourcePath(cmd.Context(), resourcePath, "folder")
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
@@ -46,26 +51,19 @@ pleasant-cli get folder --path <path>`,
 		}
 
 		subPath := pleasant.PathFolders + "/" + identifier
+		table := folderTable
 
 		if cmd.Flags().Changed("useraccess") {
 			subPath = subPath + "/useraccess"
+			table = nil
 		}
 
-		folder, err := pleasant.GetJsonBody(baseUrl, subPath, bearerToken)
+		folder, err := client.GetJsonBody(cmd.Context(), subPath)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
 
-		if cmd.Flags().Changed("pretty") {
-			output, err := pleasant.PrettyPrintJson(folder)
-			if err != nil {
-				pleasant.ExitFatal(err)
-			}
-
-			pleasant.Exit(output)
-		}
-
-		pleasant.Exit(folder)
+		printOutput(format, folder, table)
 	},
 }
 
@@ -78,7 +76,7 @@ func init() {
 	getFolderCmd.MarkFlagsOneRequired("path", "id")
 
 	getFolderCmd.RegisterFlagCompletionFunc("path", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
-		return pleasant.CompletePathFlag(toComplete, false)
+		return completePathFlag(cmd.Context(), toComplete, false)
 	})
 
 	getFolderCmd.Flags().Bool("useraccess", false, "Gets the users that have access to the folder")
@@ -15,27 +15,23 @@ WARNING: this command can take a while to complete.
 Example:
 pleasant-cli get folders`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
-
-		folder, err := pleasant.GetJsonBody(baseUrl, pleasant.PathFolders, bearerToken)
+		format, err := outputFormat(cmd)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
 
-		if cmd.Flags().Changed("pretty") {
-			output, err := pleasant.PrettyPrintJson(folder)
-			if err != nil {
-				pleasant.ExitFatal(err)
-			}
+		client := newClient()
 
-			pleasant.Exit(output)
+		folder, err := client.GetJsonBody(cmd.Context(), pleasant.PathFolders)
+		if err != nil {
+			pleasant.ExitFatal(err)
 		}
 
-		pleasant.Exit(folder)
+		printOutput(format, folder, foldersTable)
 	},
 }
 
@@ -17,34 +17,30 @@ Example:
 pleasant-cli get passwordstrength --password <PASSWORD>
 pleasant-cli get passwordstrength -p <PASSWORD>`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
-
-		pw, err := cmd.Flags().GetString("password")
+		format, err := outputFormat(cmd)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
 
-		json := fmt.Sprintf(`{"Password":"%v"}`, pw)
+		client := newClient()
 
-		pwStr, err := pleasant.PostJsonString(baseUrl, pleasant.PathPwStr, json, bearerToken)
+		pw, err := cmd.Flags().GetString("password")
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
 
-		if cmd.Flags().Changed("pretty") {
-			output, err := pleasant.PrettyPrintJson(pwStr)
-			if err != nil {
-				pleasant.ExitFatal(err)
-			}
+		json := fmt.Sprintf(`{"Password":"%v"}`, pw)
 
-			pleasant.Exit(output)
+		pwStr, err := client.PostJsonString(cmd.Context(), pleasant.PathPwStr, json)
+		if err != nil {
+			pleasant.ExitFatal(err)
 		}
 
-		pleasant.Exit(pwStr)
+		printOutput(format, pwStr, nil)
 	},
 }
 
@@ -14,27 +14,23 @@ var getRootfolderCmd = &cobra.Command{
 Example:
 pleasant-cli get rootfolder`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
-
-		rootFolderId, err := pleasant.GetJsonBody(baseUrl, pleasant.PathRootFolder, bearerToken)
+		format, err := outputFormat(cmd)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
 
-		if cmd.Flags().Changed("pretty") {
-			output, err := pleasant.PrettyPrintJson(rootFolderId)
-			if err != nil {
-				pleasant.ExitFatal(err)
-			}
+		client := newClient()
 
-			pleasant.Exit(output)
+		rootFolderId, err := client.GetJsonBody(cmd.Context(), pleasant.PathRootFolder)
+		if err != nil {
+			pleasant.ExitFatal(err)
 		}
 
-		pleasant.Exit(rootFolderId)
+		printOutput(format, rootFolderId, nil)
 	},
 }
 
@@ -15,27 +15,23 @@ OS information and DNS settings.
 Example:
 pleasant-cli get serverinfo`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
-
-		serverInfo, err := pleasant.GetJsonBody(baseUrl, pleasant.PathServerInfo, bearerToken)
+		format, err := outputFormat(cmd)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
 
-		if cmd.Flags().Changed("pretty") {
-			output, err := pleasant.PrettyPrintJson(serverInfo)
-			if err != nil {
-				pleasant.ExitFatal(err)
-			}
+		client := newClient()
 
-			pleasant.Exit(output)
+		serverInfo, err := client.GetJsonBody(cmd.Context(), pleasant.PathServerInfo)
+		if err != nil {
+			pleasant.ExitFatal(err)
 		}
 
-		pleasant.Exit(serverInfo)
+		printOutput(format, serverInfo, nil)
 	},
 }
 
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+// injectCmd represents the inject command
+var injectCmd = &cobra.Command{
+	Use:   "inject",
+	Short: "Replaces secret references in a template",
+	Long: `Replaces secret references in a template, e.g. a config file, with fields of entries.
+
+A reference has the format 'pleasant://Root/Folder/Entry#field' or 'pleasant://id/<id>#field'.
+Without '#field', the password is used. Field names are the same as for 'get entry --field'.
+References end at whitespace, quotes, brackets, ',' or ';', so they can be used in lists and function calls.
+Use percent-encoding for these characters in names, e.g. 'pleasant://Root/My%20Folder/Entry#username',
+and escape a '/' in a name with a backslash.
+
+The template is read from --in or stdin and written to --out or stdout.
+An output file is replaced atomically and is only accessible by the current user.
+
+Examples:
+pleasant-cli inject --in config.yaml.tpl --out config.yaml
+cat config.yaml.tpl | pleasant-cli inject > config.yaml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
+			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
+		}
+
+		in, err := cmd.Flags().GetString("in")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		out, err := cmd.Flags().GetString("out")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		var tmpl []byte
+
+		if in == "-" {
+			tmpl, err = io.ReadAll(os.Stdin)
+		} else {
+			tmpl, err = os.ReadFile(in)
+		}
+
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		client := newClient()
+
+		result, err := client.InjectReferences(cmd.Context(), string(tmpl))
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		if out == "-" {
+			_, err = os.Stdout.WriteString(result)
+			if err != nil {
+				pleasant.ExitFatal(err)
+			}
+
+			return
+		}
+
+		err = pleasant.WritePrivateFile(out, []byte(result))
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		pleasant.Exit("Template rendered to:", out)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(injectCmd)
+
+	injectCmd.Flags().String("in", "-", "Template file, '-' for stdin")
+	injectCmd.Flags().String("out", "-", "Output file, '-' for stdout")
+}
@@ -3,10 +3,10 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
 	"github.com/marevers/pleasant-cli/pleasant"
 )
@@ -16,52 +16,134 @@ var loginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Log in to Pleasant Password Server",
 	Long: `Log into Pleasant Password Server with username and password.
-Username and password can either be entered interactively or by using flags.
+Username and password can either be entered interactively, by using flags or by setting the
+PLEASANT_USERNAME and PLEASANT_PASSWORD environment variables. With --password-stdin, the password
+is read from the first line of stdin.
+
+If two-factor authentication is enabled for the user, the one-time password is prompted for
+after the username and password have been accepted. It can also be supplied with --otp.
 
 Examples:
 pleasant-cli login
-pleasant-cli login --username <USERNAME> --password <PASSWORD>`,
+pleasant-cli login --username <USERNAME> --password <PASSWORD>
+pleasant-cli login --username <USERNAME> --password <PASSWORD> --otp <ONE-TIME PASSWORD>
+echo <PASSWORD> | pleasant-cli login --username <USERNAME> --password-stdin`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet()) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		var username string
-		var password string
-
-		if cmd.Flag("username").Value.String() != "" || cmd.Flag("password").Value.String() != "" {
-			username = cmd.Flag("username").Value.String()
-			password = cmd.Flag("password").Value.String()
-		} else {
-			username = pleasant.StringPrompt("Enter username:")
-			password = pleasant.PasswordPrompt("Enter password:")
+		username, password, err := loginCredentials(cmd)
+		if err != nil {
+			pleasant.ExitFatal(err)
 		}
 
 		fmt.Println("Logging in to Pleasant Password Server...")
 
-		bearerToken, err := pleasant.GetBearerToken(viper.GetString("serverUrl"), username, password)
+		client := newClient()
+
+		bearerToken, err := client.GetBearerToken(cmd.Context(), username, password)
+
+		var otpErr *pleasant.OtpRequiredError
+		if errors.As(err, &otpErr) {
+			otp, flagErr := cmd.Flags().GetString("otp")
+			if flagErr != nil {
+				pleasant.ExitFatal(flagErr)
+			}
+
+			if otp == "" {
+				// Prompting is only possible on a terminal, stdin may also hold the password
+				if !pleasant.IsStdinTerminal() {
+					pleasant.ExitFatal(fmt.Errorf("%w, use --otp", otpErr))
+				}
+
+				otp, err = pleasant.StringPrompt(fmt.Sprintf("Enter one-time password (%v):", otpErr.Provider))
+				if err != nil {
+					pleasant.ExitFatal(fmt.Errorf("%w: %w", otpErr, err))
+				}
+			}
+
+			bearerToken, err = client.GetBearerTokenWithOtp(cmd.Context(), username, password, otpErr.Provider, otp)
+		}
+
 		if errors.Is(err, pleasant.ErrBadRequest) {
 			pleasant.ExitFatal(pleasant.ErrInvalidCredentials)
 		} else if err != nil {
 			pleasant.ExitFatal(err)
 		}
 
-		t := time.Now()
-		ea := t.Unix() + int64(bearerToken.ExpiresIn)
+		token := bearerToken.Token()
 
-		err = pleasant.WriteTokenFile(tokenFile, bearerToken.AccessToken, ea)
+		err = tokenStore.Save(token)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
 
-		pleasant.Exit("Successfully logged in. Token saved to:", tokenFile, ", valid until", time.Unix(ea, 0))
+		pleasant.Exit("Successfully logged in. Token saved to:", tokenStore.Location(), ", valid until", time.Unix(token.ExpiresAt, 0))
 	},
 }
 
+// loginCredentials returns the username and password from the flags, stdin or the environment, in that order
+// Credentials that are not set are prompted for
+func loginCredentials(cmd *cobra.Command) (string, string, error) {
+	username, err := cmd.Flags().GetString("username")
+	if err != nil {
+		return "", "", err
+	}
+
+	password, err := cmd.Flags().GetString("password")
+	if err != nil {
+		return "", "", err
+	}
+
+	passwordStdin, err := cmd.Flags().GetBool("password-stdin")
+	if err != nil {
+		return "", "", err
+	}
+
+	if username == "" {
+		username = os.Getenv(pleasant.EnvUsername)
+	}
+
+	if passwordStdin {
+		if username == "" {
+			return "", "", fmt.Errorf("%w: --password-stdin requires --username or %v", pleasant.ErrInvalidCredentials, pleasant.EnvUsername)
+		}
+
+		password, err = pleasant.ReadPasswordStdin()
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	if password == "" {
+		password = os.Getenv(pleasant.EnvPassword)
+	}
+
+	if username == "" {
+		username, err = pleasant.StringPrompt("Enter username:")
+		if err != nil {
+			return "", "", fmt.Errorf("%w: %w", pleasant.ErrInvalidCredentials, err)
+		}
+	}
+
+	if password == "" {
+		password, err = pleasant.PasswordPrompt("Enter password:")
+		if err != nil {
+			return "", "", fmt.Errorf("%w: %w", pleasant.ErrInvalidCredentials, err)
+		}
+	}
+
+	return username, password, nil
+}
+
 func init() {
 	rootCmd.AddCommand(loginCmd)
 
 	loginCmd.Flags().StringP("username", "u", "", "Username for Pleasant Password Server")
 	loginCmd.Flags().StringP("password", "p", "", "Password for Pleasant Password Server")
-	loginCmd.MarkFlagsRequiredTogether("username", "password")
+	loginCmd.Flags().Bool("password-stdin", false, "Read the password from stdin")
+	loginCmd.MarkFlagsMutuallyExclusive("password", "password-stdin")
+
+	loginCmd.Flags().String("otp", "", "One-time password for two-factor authentication")
 }
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+// lsCmd represents the ls command
+var lsCmd = &cobra.Command{
+	Use:   "ls [PATH]",
+	Short: "Lists the contents of a folder",
+	Long: `Lists the folders and entries in a folder, like ls does for a directory.
+A path must be absolute and starts with 'Root/', e.g. 'Root/Folder1/Folder2'. The default path is 'Root'.
+Folders are listed first and end with a '/'.
+
+To show the username, URL, expiry date and tags of each entry, use -l.
+To list the contents of all subfolders, use -R. The number of levels can be limited with --depth.
+Entries and folders are sorted by name, use --sort to sort by username or expiry date instead.
+
+Examples:
+pleasant-cli ls
+pleasant-cli ls Root/Folder1
+pleasant-cli ls -l Root/Folder1
+pleasant-cli ls -R --depth 2 Root/Folder1
+pleasant-cli ls -l --sort expires --reverse Root/Folder1`,
+	Args: cobra.MatchAll(cobra.MaximumNArgs(1)),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return completePathFlag(cmd.Context(), toComplete, false)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
+			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
+		}
+
+		resourcePath := "Root"
+		if len(args) > 0 {
+			resourcePath = args[0]
+		}
+
+		long, err := cmd.Flags().GetBool("long")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		recursive, err := cmd.Flags().GetBool("recursive")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		depth, err := cmd.Flags().GetInt("depth")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		sortKey, err := cmd.Flags().GetString("sort")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		reverse, err := cmd.Flags().GetBool("reverse")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		if !slices.Contains(lsSortKeys, sortKey) {
+			pleasant.ExitFatal(fmt.Errorf("%w, must be one of: %v", pleasant.ErrInvalidSortKey, strings.Join(lsSortKeys, ", ")))
+		}
+
+		client := newClient()
+
+		id, err := client.GetIdByResourcePath(cmd.Context(), resourcePath, "folder")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		folder, err := client.GetFolderTree(cmd.Context(), id, lsFetchDepth(recursive, depth))
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		l := &lister{
+			w:         os.Stdout,
+			long:      long,
+			recursive: recursive,
+			depth:     depth,
+			sortKey:   sortKey,
+			reverse:   reverse,
+		}
+
+		segments := pleasant.SplitPath(resourcePath)
+		if len(segments) > 1 && segments[len(segments)-1] == "" {
+			segments = segments[:len(segments)-1]
+		}
+
+		l.list(folder, pleasant.JoinPath(segments...), 0)
+	},
+}
+
+// lsFetchDepth returns the number of levels of the folder tree that ls needs
+// The contents of the folders on the last listed level are one level below them
+func lsFetchDepth(recursive bool, depth int) int {
+	switch {
+	case !recursive:
+		return 1
+	case depth > 0:
+		return depth + 1
+	}
+
+	return 0
+}
+
+// lsSortKeys are the values accepted by ls --sort
+var lsSortKeys = []string{"name", "username", "expires"}
+
+// lister prints the contents of folders for the ls command
+type lister struct {
+	w         io.Writer
+	long      bool
+	recursive bool
+	depth     int
+	sortKey   string
+	reverse   bool
+}
+
+// list prints the contents of folder and, if recursive, of its subfolders
+func (l *lister) list(folder *pleasant.Folder, path string, level int) {
+	folders := slices.Clone(folder.Children)
+	entries := slices.Clone(folder.Credentials)
+
+	slices.SortStableFunc(folders, func(a, b pleasant.Folder) int {
+		return l.compare(a.Name, b.Name, "", "", a.Expires, b.Expires)
+	})
+
+	slices.SortStableFunc(entries, func(a, b pleasant.Entry) int {
+		return l.compare(a.Name, b.Name, a.Username, b.Username, a.Expires, b.Expires)
+	})
+
+	if l.recursive {
+		if level > 0 {
+			fmt.Fprintln(l.w)
+		}
+
+		fmt.Fprintln(l.w, path+":")
+	}
+
+	if l.long {
+		tw := tabwriter.NewWriter(l.w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tUSERNAME\tURL\tEXPIRES\tTAGS")
+
+		for _, f := range folders {
+			fmt.Fprintf(tw, "%v/\t\t\t%v\t%v\n", pleasant.EscapePathSegment(f.Name), formatExpires(f.Expires), formatTags(f.Tags))
+		}
+
+		for _, e := range entries {
+			fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\n", pleasant.EscapePathSegment(e.Name), e.Username, e.Url, formatExpires(e.Expires), formatTags(e.Tags))
+		}
+
+		tw.Flush()
+	} else {
+		for _, f := range folders {
+			fmt.Fprintln(l.w, pleasant.EscapePathSegment(f.Name)+"/")
+		}
+
+		for _, e := range entries {
+			fmt.Fprintln(l.w, pleasant.EscapePathSegment(e.Name))
+		}
+	}
+
+	if !l.recursive || (l.depth > 0 && level >= l.depth) {
+		return
+	}
+
+	for i := range folders {
+		l.list(&folders[i], path+"/"+pleasant.EscapePathSegment(folders[i].Name), level+1)
+	}
+}
+
+// compare orders two folders or entries by the sort key, falling back to the name
+func (l *lister) compare(nameA, nameB, usernameA, usernameB, expiresA, expiresB string) int {
+	var c int
+
+	switch l.sortKey {
+	case "username":
+		c = cmp.Compare(strings.ToLower(usernameA), strings.ToLower(usernameB))
+	case "expires":
+		c = compareExpires(expiresA, expiresB)
+	}
+
+	if c == 0 {
+		c = cmp.Compare(strings.ToLower(nameA), strings.ToLower(nameB))
+	}
+
+	if l.reverse {
+		return -c
+	}
+
+	return c
+}
+
+// compareExpires orders expiry dates from earliest to latest, items that do not expire come last
+func compareExpires(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	default:
+		return cmp.Compare(a, b)
+	}
+}
+
+// formatExpires returns the date part of an expiry timestamp
+func formatExpires(expires string) string {
+	date, _, _ := strings.Cut(expires, "T")
+	return date
+}
+
+func formatTags(tags []pleasant.Tag) string {
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+	}
+
+	return strings.Join(names, ",")
+}
+
+func init() {
+	rootCmd.AddCommand(lsCmd)
+
+	lsCmd.Flags().BoolP("long", "l", false, "Show the username, URL, expiry date and tags of each entry")
+	lsCmd.Flags().BoolP("recursive", "R", false, "List the contents of all subfolders")
+	lsCmd.Flags().Int("depth", 0, "Maximum number of subfolder levels to list with -R, 0 lists all levels")
+	lsCmd.Flags().String("sort", "name", "Sort by 'name', 'username' or 'expires'")
+	lsCmd.Flags().BoolP("reverse", "r", false, "Reverse the sort order")
+
+	lsCmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(lsSortKeys, cobra.ShellCompDirectiveNoFileComp))
+}
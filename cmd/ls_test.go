@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+func TestLsFetchDepth(t *testing.T) {
+	tests := []struct {
+		recursive bool
+		depth     int
+		want      int
+	}{
+		{recursive: false, depth: 0, want: 1},
+		{recursive: false, depth: 2, want: 1},
+		{recursive: true, depth: 0, want: 0},
+		{recursive: true, depth: 1, want: 2},
+		{recursive: true, depth: 3, want: 4},
+	}
+
+	for _, tt := range tests {
+		if got := lsFetchDepth(tt.recursive, tt.depth); got != tt.want {
+			t.Errorf("lsFetchDepth(%v, %v) = %v, want %v", tt.recursive, tt.depth, got, tt.want)
+		}
+	}
+}
+
+func TestListerDepth(t *testing.T) {
+	// A tree as returned for --depth 1, the folders on level 1 have their contents but not their subfolders' contents
+	folder := &pleasant.Folder{
+		Name: "Root",
+		Children: []pleasant.Folder{
+			{
+				Name:        "A",
+				Children:    []pleasant.Folder{{Name: "Deep"}},
+				Credentials: []pleasant.Entry{{Name: "E1"}},
+			},
+		},
+		Credentials: []pleasant.Entry{{Name: "Top"}},
+	}
+
+	var buf bytes.Buffer
+
+	l := &lister{
+		w:         &buf,
+		recursive: true,
+		depth:     1,
+		sortKey:   "name",
+	}
+
+	l.list(folder, "Root", 0)
+
+	want := "Root:\nA/\nTop\n\nRoot/A:\nDeep/\nE1\n"
+	if got := buf.String(); got != want {
+		t.Errorf("list() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+// mvCmd represents the mv command
+var mvCmd = &cobra.Command{
+	Use:   "mv SRC... DST",
+	Short: "Moves or renames entries and folders",
+	Long: `Moves or renames entries and folders, like mv does for files.
+Sources and destination can be paths or ids. A path must be absolute and starts with 'Root/',
+e.g. 'Root/Folder1/Entry'. A path that matches both a folder and an entry refers to the folder.
+
+If the destination is an existing folder, the sources are moved into it and keep their names.
+Otherwise the source is moved to the parent folder of the destination and renamed to the last
+component of the destination path. Multiple sources can only be moved into an existing folder.
+A folder cannot be moved into itself or one of its subfolders.
+
+If an entry or folder with the same name already exists at the destination, nothing is moved
+unless --force is used. The source is then moved under a temporary name, the existing entry or
+folder is archived and the source is renamed to its final name.
+
+Examples:
+pleasant-cli mv Root/Folder1/Entry Root/Folder2
+pleasant-cli mv Root/Folder1/Entry Root/Folder1/RenamedEntry
+pleasant-cli mv Root/Folder1/Entry1 Root/Folder1/Entry2 Root/Folder2
+pleasant-cli mv <id> Root/Folder2/NewName
+pleasant-cli mv --force Root/Folder1/Entry Root/Folder2/Entry`,
+	Args: cobra.MatchAll(cobra.MinimumNArgs(2)),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return completePathFlag(cmd.Context(), toComplete, true)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
+			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
+		}
+
+		force, err := cmd.Flags().GetBool("force")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		client := newClient()
+
+		sources := args[:len(args)-1]
+
+		dst, err := resolveDestination(cmd.Context(), client, args[len(args)-1], len(sources) > 1)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		for _, src := range sources {
+			id, resourceType, err := client.ResolveResource(cmd.Context(), src)
+			if err != nil {
+				pleasant.ExitFatal(fmt.Errorf("%w: %v", err, src))
+			}
+
+			target, err := move(cmd.Context(), client, id, resourceType, dst, force)
+			if err != nil {
+				pleasant.ExitFatal(err)
+			}
+
+			fmt.Println("Moved", src, "to", target)
+		}
+	},
+}
+
+// destination is where mv or cp puts its sources
+type destination struct {
+	// FolderId is the id of the folder the sources are put in
+	FolderId string
+	// Name is the new name of the source, or empty to keep the name of the source
+	Name string
+	// Display is the destination as given on the command line, without a trailing slash
+	Display string
+}
+
+// target returns the destination of a source with the given name, for printing
+func (d *destination) target(name string) string {
+	if d.Name != "" {
+		return d.Display
+	}
+
+	return d.Display + "/" + pleasant.EscapePathSegment(name)
+}
+
+// resolveDestination resolves the destination of mv or cp
+// An existing folder is the folder the sources are put in, otherwise the destination must be a path
+// with an existing parent folder and only a single source
+func resolveDestination(ctx context.Context, client *pleasant.Client, dst string, multipleSources bool) (*destination, error) {
+	d := &destination{
+		Display: dst,
+	}
+
+	segments := pleasant.SplitPath(dst)
+	if len(segments) > 1 && segments[len(segments)-1] == "" {
+		segments = segments[:len(segments)-1]
+		d.Display = pleasant.JoinPath(segments...)
+	}
+
+	id, resourceType, err := client.ResolveResource(ctx, dst)
+
+	switch {
+	case err == nil && resourceType == "folder":
+		d.FolderId = id
+		return d, nil
+	case err == nil && (multipleSources || segments[0] != "Root"):
+		// An existing entry given by id has no path to take the new name from
+		return nil, fmt.Errorf("%w: %v", pleasant.ErrDestinationNoFolder, dst)
+	case err != nil && !errors.Is(err, pleasant.ErrNotFound):
+		return nil, err
+	case err != nil && (multipleSources || segments[0] != "Root"):
+		return nil, fmt.Errorf("%w: %v", pleasant.ErrDestinationNoFolder, dst)
+	}
+
+	// The destination is an existing entry or a new name in an existing folder
+	d.FolderId, err = client.GetParentIdByResourcePath(ctx, d.Display)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Name = segments[len(segments)-1]
+
+	return d, nil
+}
+
+// move moves the entry or folder with the given id to dst and returns the new location for printing
+func move(ctx context.Context, client *pleasant.Client, id, resourceType string, dst *destination, force bool) (string, error) {
+	var name, parentId string
+
+	if resourceType == "entry" {
+		e, err := client.GetEntry(ctx, id)
+		if err != nil {
+			return "", err
+		}
+
+		name, parentId = e.Name, e.GroupId
+	} else {
+		f, err := client.GetFolderTree(ctx, id, 1)
+		if err != nil {
+			return "", err
+		}
+
+		name, parentId = f.Name, f.ParentId
+
+		err = checkNotDescendant(ctx, client, id, dst.FolderId)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	newName := name
+	if dst.Name != "" {
+		newName = dst.Name
+	}
+
+	target := dst.target(name)
+
+	if parentId == dst.FolderId && newName == name {
+		return target, nil
+	}
+
+	existingId, err := existingChildId(ctx, client, dst.FolderId, newName, resourceType)
+	if err != nil {
+		return "", err
+	}
+
+	if existingId == "" || existingId == id {
+		return target, patchLocation(ctx, client, id, resourceType, dst.FolderId, newName)
+	}
+
+	if !force {
+		return "", fmt.Errorf("%w: %v", pleasant.ErrDestinationExists, target)
+	}
+
+	// The source is moved under a temporary name first, so the existing entry or folder is only
+	// archived once the source could be moved
+	tempName := newName + ".mv-" + id
+
+	err = patchLocation(ctx, client, id, resourceType, dst.FolderId, tempName)
+	if err != nil {
+		return "", err
+	}
+
+	if resourceType == "entry" {
+		err = client.DeleteEntry(ctx, existingId, pleasant.ActionArchive)
+	} else {
+		err = client.DeleteFolder(ctx, existingId, pleasant.ActionArchive)
+	}
+
+	if err != nil {
+		restoreErr := patchLocation(ctx, client, id, resourceType, parentId, name)
+		if restoreErr != nil {
+			return "", fmt.Errorf("%w: %v %v was left in the destination folder as '%v': %w", err, resourceType, id, tempName, restoreErr)
+		}
+
+		return "", err
+	}
+
+	err = patchLocation(ctx, client, id, resourceType, dst.FolderId, newName)
+	if err != nil {
+		return "", fmt.Errorf("%w: existing %v %v was archived and %v %v was left in the destination folder as '%v'", err, resourceType, existingId, resourceType, id, tempName)
+	}
+
+	return target, nil
+}
+
+// checkNotDescendant returns ErrMoveIntoItself if the folder with folderId is the folder with id or one of its subfolders
+func checkNotDescendant(ctx context.Context, client *pleasant.Client, id, folderId string) error {
+	seen := map[string]bool{}
+
+	for folderId != "" && !seen[folderId] {
+		if folderId == id {
+			return fmt.Errorf("%w: %v", pleasant.ErrMoveIntoItself, id)
+		}
+
+		seen[folderId] = true
+
+		folder, err := client.GetFolderTree(ctx, folderId, 1)
+		if err != nil {
+			return err
+		}
+
+		folderId = folder.ParentId
+	}
+
+	return nil
+}
+
+// patchLocation moves the entry or folder with the given id to the folder with parentId and renames it to name
+func patchLocation(ctx context.Context, client *pleasant.Client, id, resourceType, parentId, name string) error {
+	if resourceType == "entry" {
+		return client.PatchEntry(ctx, id, &pleasant.Entry{GroupId: parentId, Name: name})
+	}
+
+	return client.PatchFolder(ctx, id, &pleasant.Folder{ParentId: parentId, Name: name})
+}
+
+// existingChildId returns the id of the entry or folder with the given name in a folder, or an empty string
+func existingChildId(ctx context.Context, client *pleasant.Client, folderId, name, resourceType string) (string, error) {
+	// Only the direct children are needed, not the full tree below the folder
+	folder, err := client.GetFolderTree(ctx, folderId, 1)
+	if err != nil {
+		return "", err
+	}
+
+	if resourceType == "entry" {
+		for _, e := range folder.Credentials {
+			if e.Name == name {
+				return e.Id, nil
+			}
+		}
+	} else {
+		for _, f := range folder.Children {
+			if f.Name == name {
+				return f.Id, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+func init() {
+	rootCmd.AddCommand(mvCmd)
+
+	mvCmd.Flags().BoolP("force", "f", false, "Archive an existing entry or folder with the same name at the destination")
+}
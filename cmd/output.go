@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+var outputFlag string
+
+// Tables for --output table and wide
+var (
+	entryTable = &pleasant.Table{
+		Columns: []pleasant.Column{
+			{Header: "ID", Value: "{.Id}"},
+			{Header: "NAME", Value: "{.Name}"},
+			{Header: "USERNAME", Value: "{.Username}"},
+			{Header: "URL", Value: "{.Url}"},
+			{Header: "GROUPID", Value: "{.GroupId}", Wide: true},
+			{Header: "EXPIRES", Value: "{.Expires}", Wide: true},
+			{Header: "TAGS", Value: "{.Tags[*].Name}", Wide: true},
+		},
+	}
+
+	attachmentsTable = &pleasant.Table{
+		Columns: []pleasant.Column{
+			{Header: "ID", Value: "{.AttachmentId}"},
+			{Header: "NAME", Value: "{.FileName}"},
+			{Header: "SIZE", Value: "{.FileSize}"},
+		},
+	}
+
+	folderTable = &pleasant.Table{
+		Columns: []pleasant.Column{
+			{Header: "ID", Value: "{.Id}"},
+			{Header: "NAME", Value: "{.Name}"},
+			{Header: "PARENTID", Value: "{.ParentId}"},
+			{Header: "EXPIRES", Value: "{.Expires}", Wide: true},
+			{Header: "TAGS", Value: "{.Tags[*].Name}", Wide: true},
+		},
+	}
+
+	// foldersTable shows the root folder and all folders below it
+	foldersTable = &pleasant.Table{
+		Rows:    "{$}{..Children[*]}",
+		Columns: folderTable.Columns,
+	}
+
+	// searchTable shows the matching folders followed by the matching entries
+	searchTable = &pleasant.Table{
+		Rows: "{.Groups[*]}{.Credentials[*]}",
+		Columns: []pleasant.Column{
+			{Header: "ID", Value: "{.Id}"},
+			{Header: "NAME", Value: "{.Name}"},
+			{Header: "PATH", Value: "{.FullPath}{.Path}"},
+			{Header: "USERNAME", Value: "{.Username}", Wide: true},
+			{Header: "URL", Value: "{.Url}", Wide: true},
+		},
+	}
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "", "Output format of get and search commands, one of: "+strings.Join(pleasant.OutputFormats, ", "))
+
+	rootCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(pleasant.OutputFormats, cobra.ShellCompDirectiveNoFileComp|cobra.ShellCompDirectiveNoSpace))
+}
+
+// outputFormat returns the output format selected with --output or --pretty, nil prints the JSON as returned by the server
+func outputFormat(cmd *cobra.Command) (*pleasant.Output, error) {
+	if outputFlag != "" {
+		return pleasant.ParseOutput(outputFlag)
+	}
+
+	// Commands without --pretty return an error, which is the same as --pretty=false
+	if pretty, err := cmd.Flags().GetBool("pretty"); err == nil && pretty {
+		return pleasant.ParseOutput("json")
+	}
+
+	return nil, nil
+}
+
+// printOutput prints the JSON result of a command in the output format and exits
+func printOutput(format *pleasant.Output, result string, table *pleasant.Table) {
+	if format == nil {
+		pleasant.Exit(result)
+	}
+
+	s, err := format.Render(result, table)
+	if err != nil {
+		pleasant.ExitFatal(err)
+	}
+
+	pleasant.Exit(strings.TrimSuffix(s, "\n"))
+}
@@ -11,7 +11,9 @@ import (
 var patchEntryCmd = &cobra.Command{
 	Use:   "entry",
 	Short: "Partially updates an entry",
-	Long: `Applies a partial update to an entry in Pleasant Password Server. Takes a JSON string as input.
+	Long: `Applies a partial update to an entry in Pleasant Password Server. Takes JSON as input.
+The JSON can be passed as a string with --data, read from a file with '--data @FILE' or from stdin with '--data -'.
+With --file, the input is read from a YAML or JSON file, use '--file -' for stdin.
 
 To add a user access assignment to an entry, use --useraccess.
 You can find available PermissionSetIds by running 'pleasant-cli get accesslevels'.
@@ -34,15 +36,17 @@ pleasant-cli patch entry --path 'Root/Folder1/TestEntry' --useraccess --data '
 	"ZoneId": "",
 	"PermissionSetId": "6fe3319c-21f0-48b0-a274-22fcca660de3",
 	"AccessExpiry": "2020-12-31"
-}'`,
+}'
+
+echo '{"Password": "MyNewPassword01"}' | pleasant-cli patch entry --path 'Root/Folder1/TestEntry' --data -`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
+		client := newClient()
 
-		json, err := cmd.Flags().GetString("data")
+		json, err := readData(cmd)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
@@ -55,7 +59,7 @@ pleasant-cli patch entry --path 'Root/Folder1/TestEntry' --useraccess --data '
 				pleasant.ExitFatal(err)
 			}
 
-			id, err := pleasant.GetIdByResourcePath(baseUrl, resourcePath, "entry", bearerToken)
+			id, err := client.GetIdByResourcePath(cmd.Context(), resourcePath, "entry")
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
@@ -79,14 +83,14 @@ pleasant-cli patch entry --path 'Root/Folder1/TestEntry' --useraccess --data '
 
 			msg = fmt.Sprintf("User access assignment for entry %v added", identifier)
 
-			_, err := pleasant.PostJsonString(baseUrl, subPath, json, bearerToken)
+			_, err := client.PostJsonString(cmd.Context(), subPath, json)
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
 		} else {
 			msg = fmt.Sprintf("Existing entry with id %v patched", identifier)
 
-			_, err = pleasant.PatchJsonString(baseUrl, subPath, json, bearerToken)
+			_, err = client.PatchJsonString(cmd.Context(), subPath, json)
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
@@ -105,11 +109,10 @@ func init() {
 	patchEntryCmd.MarkFlagsOneRequired("path", "id")
 
 	patchEntryCmd.RegisterFlagCompletionFunc("path", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
-		return pleasant.CompletePathFlag(toComplete, true)
+		return completePathFlag(cmd.Context(), toComplete, true)
 	})
 
-	patchEntryCmd.Flags().StringP("data", "d", "", "JSON string with partial update/user access assignment")
-	patchEntryCmd.MarkFlagRequired("data")
+	addDataFlags(patchEntryCmd, "JSON string with partial update/user access assignment")
 
 	patchEntryCmd.Flags().Bool("useraccess", false, "Add user access assignment to the entry")
 }
@@ -11,7 +11,9 @@ import (
 var patchFolderCmd = &cobra.Command{
 	Use:   "folder",
 	Short: "Partially updates a folder",
-	Long: `Applies a partial update to a folder in Pleasant Password Server. Takes a JSON string as input.
+	Long: `Applies a partial update to a folder in Pleasant Password Server. Takes JSON as input.
+The JSON can be passed as a string with --data, read from a file with '--data @FILE' or from stdin with '--data -'.
+With --file, the input is read from a YAML or JSON file, use '--file -' for stdin.
 
 To add a user access assignment to a folder, use --useraccess.
 You can find available PermissionSetIds by running 'pleasant-cli get accesslevels'.
@@ -34,15 +36,17 @@ pleasant-cli patch folder --path 'Root/Folder1/TestFolder' --useraccess --data '
 	"ZoneId": "",
 	"PermissionSetId": "6fe3319c-21f0-48b0-a274-22fcca660de3",
 	"AccessExpiry": "2020-12-31"
-}'`,
+}'
+
+pleasant-cli patch folder --path 'Root/Folder1/TestFolder' --file patch.yaml`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
+		client := newClient()
 
-		json, err := cmd.Flags().GetString("data")
+		json, err := readData(cmd)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
@@ -55,7 +59,7 @@ pleasant-cli patch folder --path 'Root/Folder1/TestFolder' --useraccess --data '
 				pleasant.ExitFatal(err)
 			}
 
-			id, err := pleasant.GetIdByResourcePath(baseUrl, resourcePath, "folder", bearerToken)
+			id, err := client.GetIdByResourcePath(cmd.Context(), resourcePath, "folder")
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
@@ -79,14 +83,14 @@ pleasant-cli patch folder --path 'Root/Folder1/TestFolder' --useraccess --data '
 
 			msg = fmt.Sprintf("User access assignment for folder %v added", identifier)
 
-			_, err := pleasant.PostJsonString(baseUrl, subPath, json, bearerToken)
+			_, err := client.PostJsonString(cmd.Context(), subPath, json)
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
 		} else {
 			msg = fmt.Sprintf("Existing folder with id %v patched", identifier)
 
-			_, err = pleasant.PatchJsonString(baseUrl, subPath, json, bearerToken)
+			_, err = client.PatchJsonString(cmd.Context(), subPath, json)
 			if err != nil {
 				pleasant.ExitFatal(err)
 			}
@@ -105,11 +109,10 @@ func init() {
 	patchFolderCmd.MarkFlagsOneRequired("path", "id")
 
 	patchFolderCmd.RegisterFlagCompletionFunc("path", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
-		return pleasant.CompletePathFlag(toComplete, false)
+		return completePathFlag(cmd.Context(), toComplete, false)
 	})
 
-	patchFolderCmd.Flags().StringP("data", "d", "", "JSON string with partial update/user access assignment")
-	patchFolderCmd.MarkFlagRequired("data")
+	addDataFlags(patchFolderCmd, "JSON string with partial update/user access assignment")
 
 	patchFolderCmd.Flags().Bool("useraccess", false, "Add user access assignment to the folder")
 }
@@ -1,16 +1,24 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"github.com/marevers/pleasant-cli/pleasant"
 )
 
 var cfgFile string
 var tokenFile string
+var profile string
+var tokenStore pleasant.TokenStore
+var indexFile string
 
 // Pleasant-CLI version
 var version = "v0.11.1"
@@ -32,8 +40,19 @@ pleasant-cli login`,
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// The context passed to the commands is cancelled on an interrupt or termination signal.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Only the first signal cancels the context, a second one terminates pleasant-cli as usual.
+	// Otherwise it could not be interrupted while blocked outside of the context, e.g. in a prompt
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	err := rootCmd.ExecuteContext(ctx)
 	if err != nil {
 		os.Exit(1)
 	}
@@ -44,6 +63,7 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.pleasant-cli.yaml)")
 	rootCmd.PersistentFlags().StringVar(&tokenFile, "token", "", "token file (default is $HOME/.pleasant-token.yaml)")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "profile to use (default is $PLEASANT_PROFILE or the current profile)")
 
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
@@ -71,28 +91,61 @@ func initConfig() {
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err != nil {
-		fmt.Println(err)
+		fmt.Fprintln(os.Stderr, err)
 	}
 
-	// Use token file from the flag
-	if tokenFile != "" {
-		// Use token file from the flag
-		viper.AddConfigPath(filepath.Dir(tokenFile))
-		viper.SetConfigType("yaml")
-		viper.SetConfigName(filepath.Base(tokenFile))
-		viper.MergeInConfig()
-	} else {
+	// Resolve the profile from the flag, the environment or the config file
+	if profile == "" {
+		profile = os.Getenv("PLEASANT_PROFILE")
+	}
+
+	if profile == "" {
+		profile = viper.GetString("currentprofile")
+	}
+
+	if profile != "" {
+		p, err := pleasant.LoadProfile(cfgFile, profile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v: %v\n", err, profile)
+
+			// Do not fall back to the server of the default configuration
+			p = &pleasant.Profile{}
+		}
+
+		viper.Set("serverurl", p.ServerUrl)
+		viper.Set("timeout", p.Timeout)
+		viper.Set("retries", p.Retries)
+		viper.Set("tokenkeyfile", p.TokenKeyFile)
+		viper.Set("indexttl", p.IndexTtl)
+		viper.Set("resolver", p.Resolver)
+
+		if tokenFile == "" {
+			tokenFile = p.TokenFile
+		}
+	}
+
+	// Use token file from the flag or the profile
+	if tokenFile == "" {
 		// Find home directory.
 		home, err := os.UserHomeDir()
 		cobra.CheckErr(err)
 
-		// Search token file in home directory with name ".pleasant-token" (without extension).
-		viper.AddConfigPath(home)
-		viper.SetConfigType("yaml")
-		viper.SetConfigName(".pleasant-token")
-		viper.MergeInConfig()
+		// Set token file path, every profile has its own token file by default
+		if profile != "" {
+			tokenFile = filepath.Join(home, ".pleasant-token-"+profile+".yaml")
+		} else {
+			tokenFile = filepath.Join(home, ".pleasant-token.yaml")
+		}
+	}
+
+	tokenStore = newTokenStore()
 
-		// Set token file path
-		tokenFile = filepath.Join(home, ".pleasant-token.yaml")
+	// The path index is stored in the user's cache directory, every profile has its own index
+	if cacheDir, err := os.UserCacheDir(); err == nil {
+		if profile != "" {
+			indexFile = filepath.Join(cacheDir, "pleasant-cli", "index-"+profile+".yaml")
+		} else {
+			indexFile = filepath.Join(cacheDir, "pleasant-cli", "index.yaml")
+		}
 	}
 }
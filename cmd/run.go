@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+// runCmd represents the run command
+var runCmd = &cobra.Command{
+	Use:   "run [flags] -- COMMAND [ARGS...]",
+	Short: "Runs a command with secrets as environment variables",
+	Long: `Runs a command with fields of entries as environment variables.
+The secrets are only passed to the environment of the command and are never written to disk.
+
+Each variable is set with '--env NAME=REFERENCE'. A reference is the path or id of an entry,
+optionally followed by ':' and a field, e.g. 'Root/Folder1/Entry:username'. Without a field,
+the password is used. Field names are the same as for 'get entry --field'.
+References in the format of 'inject', e.g. 'pleasant://Root/Folder1/Entry#username', are accepted as well.
+A path must be absolute and starts with 'Root/'. Names with a '/' or ':' are escaped with a backslash.
+
+With --env-file, variables are read from a file with a 'NAME=REFERENCE' line per variable.
+Empty lines and lines starting with '#' are ignored. Variables set with --env take precedence.
+
+Signals received by pleasant-cli are forwarded to the command and pleasant-cli exits with the
+exit code of the command.
+
+Examples:
+pleasant-cli run --env DB_PASS=Root/App/DB -- ./deploy.sh
+pleasant-cli run --env DB_USER=Root/App/DB:username --env DB_PASS=Root/App/DB:password -- ./deploy.sh
+pleasant-cli run --env-file secrets.map -- ./deploy.sh --verbose`,
+	Args: cobra.MatchAll(cobra.MinimumNArgs(1)),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
+			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
+		}
+
+		envs, err := cmd.Flags().GetStringArray("env")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		envFile, err := cmd.Flags().GetString("env-file")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		var names []string
+		var refs []*pleasant.Reference
+
+		if envFile != "" {
+			names, refs, err = readEnvFile(envFile)
+			if err != nil {
+				pleasant.ExitFatal(err)
+			}
+		}
+
+		for _, e := range envs {
+			name, ref, err := parseEnvReference(e)
+			if err != nil {
+				pleasant.ExitFatal(err)
+			}
+
+			names = append(names, name)
+			refs = append(refs, ref)
+		}
+
+		client := newClient()
+
+		values, err := client.ResolveReferences(cmd.Context(), refs)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		// Later variables take precedence, as exec.Cmd uses the last value of duplicate variables
+		env := os.Environ()
+		for i, name := range names {
+			env = append(env, name+"="+values[i])
+		}
+
+		os.Exit(runCommand(args, env))
+	},
+}
+
+// parseEnvReference parses 'NAME=REFERENCE'
+func parseEnvReference(s string) (string, *pleasant.Reference, error) {
+	name, reference, ok := strings.Cut(s, "=")
+	name = strings.TrimSpace(name)
+
+	if !ok || name == "" || strings.ContainsAny(name, " \t") {
+		return "", nil, fmt.Errorf("%w: %v, must be NAME=REFERENCE", pleasant.ErrInvalidReference, s)
+	}
+
+	reference = strings.TrimSpace(reference)
+
+	parse := pleasant.ParseReference
+	if strings.HasPrefix(reference, pleasant.ReferenceUriScheme) {
+		parse = pleasant.ParseReferenceUri
+	}
+
+	ref, err := parse(reference)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return name, ref, nil
+}
+
+// readEnvFile reads the variables of an env file with a 'NAME=REFERENCE' line per variable
+func readEnvFile(file string) ([]string, []*pleasant.Reference, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	var names []string
+	var refs []*pleasant.Reference
+
+	s := bufio.NewScanner(f)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, ref, err := parseEnvReference(line)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%v:%v: %w", file, n, err)
+		}
+
+		names = append(names, name)
+		refs = append(refs, ref)
+	}
+
+	return names, refs, s.Err()
+}
+
+// runCommand runs a command with env, forwards signals to it and returns its exit code
+func runCommand(args, env []string) int {
+	c := exec.Command(args[0], args[1:]...)
+	c.Env = env
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+
+	err := c.Start()
+	if err != nil {
+		pleasant.ExitFatal(err)
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT)
+	defer signal.Stop(sigs)
+
+	go func() {
+		for sig := range sigs {
+			c.Process.Signal(sig)
+		}
+	}()
+
+	err = c.Wait()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exitErr):
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			// Like a shell, report a command killed by a signal with 128 + the signal number
+			return 128 + int(status.Signal())
+		}
+
+		return exitErr.ExitCode()
+	default:
+		pleasant.ExitFatal(err)
+	}
+
+	return 1
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+
+	runCmd.Flags().StringArrayP("env", "e", []string{}, "Environment variable as NAME=REFERENCE, e.g. DB_PASS=Root/App/DB:password (can be repeated)")
+	runCmd.Flags().String("env-file", "", "File with a NAME=REFERENCE line per environment variable")
+	runCmd.MarkFlagsOneRequired("env", "env-file")
+
+	// Flags after the command are passed to the command
+	runCmd.Flags().SetInterspersed(false)
+}
@@ -14,32 +14,28 @@ var searchCmd = &cobra.Command{
 Example:
 pleasant-cli search --query 'MyTestEntry'`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if !pleasant.CheckPrerequisites(pleasant.IsServerUrlSet(), pleasant.IsTokenValid()) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
 			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
 		}
 
-		baseUrl, bearerToken := pleasant.LoadConfig()
-
-		query, err := cmd.Flags().GetString("query")
+		format, err := outputFormat(cmd)
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
 
-		result, err := pleasant.PostSearch(baseUrl, query, bearerToken)
+		client := newClient()
+
+		query, err := cmd.Flags().GetString("query")
 		if err != nil {
 			pleasant.ExitFatal(err)
 		}
 
-		if cmd.Flags().Changed("pretty") {
-			output, err := pleasant.PrettyPrintJson(result)
-			if err != nil {
-				pleasant.ExitFatal(err)
-			}
-
-			pleasant.Exit(output)
+		result, err := client.PostSearch(cmd.Context(), query)
+		if err != nil {
+			pleasant.ExitFatal(err)
 		}
 
-		pleasant.Exit(result)
+		printOutput(format, result, searchTable)
 	},
 }
 
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marevers/pleasant-cli/pleasant"
+)
+
+// treeCmd represents the tree command
+var treeCmd = &cobra.Command{
+	Use:   "tree",
+	Short: "Shows the folder hierarchy",
+	Long: `Shows the folder hierarchy below a folder, like tree does for a directory.
+A path must be absolute and starts with 'Root/', e.g. 'Root/Folder1/Folder2'. The default path is 'Root'.
+Folders end with a '/'.
+
+To show the entries in each folder, use --entries.
+To limit the number of levels shown, use --depth.
+To show the number of subfolders and entries in each folder, use --counts.
+
+Examples:
+pleasant-cli tree
+pleasant-cli tree --path Root/Folder1 --entries
+pleasant-cli tree --depth 2 --counts`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pleasant.CheckPrerequisites(isServerUrlSet(), pleasant.IsTokenValid(tokenStore)) {
+			pleasant.ExitFatal(pleasant.ErrPrereqNotMet)
+		}
+
+		resourcePath, err := cmd.Flags().GetString("path")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		entries, err := cmd.Flags().GetBool("entries")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		depth, err := cmd.Flags().GetInt("depth")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		counts, err := cmd.Flags().GetBool("counts")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		client := newClient()
+
+		id, err := client.GetIdByResourcePath(cmd.Context(), resourcePath, "folder")
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		// The counts of the folders on the last level need the level below them
+		fetchDepth := depth
+		if counts && depth > 0 {
+			fetchDepth++
+		}
+
+		folder, err := client.GetFolderTree(cmd.Context(), id, fetchDepth)
+		if err != nil {
+			pleasant.ExitFatal(err)
+		}
+
+		t := &treePrinter{
+			w:       os.Stdout,
+			entries: entries,
+			depth:   depth,
+			counts:  counts,
+		}
+
+		segments := pleasant.SplitPath(resourcePath)
+		if len(segments) > 1 && segments[len(segments)-1] == "" {
+			segments = segments[:len(segments)-1]
+		}
+
+		fmt.Fprintln(t.w, pleasant.JoinPath(segments...)+"/"+t.count(folder))
+
+		t.print(folder, "", 1)
+
+		summary := fmt.Sprintf("\n%v", plural(t.folders, "folder", "folders"))
+		if entries {
+			summary = summary + ", " + plural(t.entryCount, "entry", "entries")
+		}
+
+		pleasant.Exit(summary)
+	},
+}
+
+// treePrinter renders the folder hierarchy for the tree command
+type treePrinter struct {
+	w       io.Writer
+	entries bool
+	depth   int
+	counts  bool
+
+	// Number of folders and entries printed
+	folders    int
+	entryCount int
+}
+
+// print prints the subfolders and, if enabled, the entries of folder with box-drawing characters
+func (t *treePrinter) print(folder *pleasant.Folder, prefix string, level int) {
+	if t.depth > 0 && level > t.depth {
+		return
+	}
+
+	children := slices.Clone(folder.Children)
+	slices.SortStableFunc(children, func(a, b pleasant.Folder) int {
+		return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	var credentials []pleasant.Entry
+	if t.entries {
+		credentials = slices.Clone(folder.Credentials)
+		slices.SortStableFunc(credentials, func(a, b pleasant.Entry) int {
+			return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+	}
+
+	for i := range children {
+		last := i == len(children)-1 && len(credentials) == 0
+
+		fmt.Fprintln(t.w, prefix+treeBranch(last)+pleasant.EscapePathSegment(children[i].Name)+"/"+t.count(&children[i]))
+		t.folders++
+
+		t.print(&children[i], prefix+treeIndent(last), level+1)
+	}
+
+	for i, e := range credentials {
+		fmt.Fprintln(t.w, prefix+treeBranch(i == len(credentials)-1)+pleasant.EscapePathSegment(e.Name))
+		t.entryCount++
+	}
+}
+
+// count returns the number of subfolders and entries of folder, if counts are enabled
+func (t *treePrinter) count(folder *pleasant.Folder) string {
+	if !t.counts {
+		return ""
+	}
+
+	return fmt.Sprintf(" (%v, %v)", plural(len(folder.Children), "folder", "folders"), plural(len(folder.Credentials), "entry", "entries"))
+}
+
+func treeBranch(last bool) string {
+	if last {
+		return "└── "
+	}
+
+	return "├── "
+}
+
+func treeIndent(last bool) string {
+	if last {
+		return "    "
+	}
+
+	return "│   "
+}
+
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%v %v", n, singular)
+	}
+
+	return fmt.Sprintf("%v %v", n, plural)
+}
+
+func init() {
+	rootCmd.AddCommand(treeCmd)
+
+	treeCmd.Flags().StringP("path", "p", "Root", "Path to folder")
+
+	treeCmd.RegisterFlagCompletionFunc("path", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return completePathFlag(cmd.Context(), toComplete, false)
+	})
+
+	treeCmd.Flags().BoolP("entries", "e", false, "Show the entries in each folder")
+	treeCmd.Flags().IntP("depth", "L", 0, "Maximum number of levels to show, 0 shows all levels")
+	treeCmd.Flags().BoolP("counts", "c", false, "Show the number of subfolders and entries in each folder")
+}
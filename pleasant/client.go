@@ -0,0 +1,114 @@
+package pleasant
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is used when no timeout is specified
+const DefaultTimeout = 20 * time.Second
+
+// Client interacts with the API of a Pleasant Password Server instance
+type Client struct {
+	baseUrl     string
+	tokenSource TokenSource
+	httpClient  *http.Client
+	timeout     time.Duration
+	retryPolicy RetryPolicy
+	index       *pathIndexCache
+	resolver    PathResolver
+	warnings    func(error)
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithToken sets a static bearer token for the client
+func WithToken(bearerToken string) Option {
+	return func(c *Client) {
+		c.tokenSource = StaticToken(bearerToken)
+	}
+}
+
+// WithTokenSource sets the TokenSource the client obtains its bearer token from
+func WithTokenSource(ts TokenSource) Option {
+	return func(c *Client) {
+		c.tokenSource = ts
+	}
+}
+
+// WithHttpClient sets the *http.Client that is used to perform requests
+func WithHttpClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithTimeout sets the timeout for requests, a timeout of 0 uses DefaultTimeout
+// The timeout is applied as a deadline to the context of each request
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithRetryPolicy sets the policy for retrying idempotent requests (GET, PATCH and search) on transient errors
+// Zero delays are replaced by those of DefaultRetryPolicy
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		if p.BaseDelay == 0 {
+			p.BaseDelay = DefaultRetryPolicy.BaseDelay
+		}
+
+		if p.MaxDelay == 0 {
+			p.MaxDelay = DefaultRetryPolicy.MaxDelay
+		}
+
+		c.retryPolicy = p
+	}
+}
+
+// WithWarningHandler sets a function that is called with errors that do not fail a request,
+// e.g. when the path index cannot be removed after a change on the server. By default they are ignored
+func WithWarningHandler(fn func(error)) Option {
+	return func(c *Client) {
+		c.warnings = fn
+	}
+}
+
+// NewClient returns a Client for the server at baseUrl
+func NewClient(baseUrl string, opts ...Option) *Client {
+	c := &Client{
+		baseUrl:     strings.TrimSuffix(baseUrl, "/"),
+		tokenSource: StaticToken(""),
+		retryPolicy: DefaultRetryPolicy,
+		resolver:    ResolverSearch,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.timeout == 0 {
+		c.timeout = DefaultTimeout
+	}
+
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{}
+	}
+
+	return c
+}
+
+// warn reports an error that does not fail a request to the warning handler
+func (c *Client) warn(err error) {
+	if c.warnings != nil {
+		c.warnings(err)
+	}
+}
+
+// BaseUrl returns the server URL of the client
+func (c *Client) BaseUrl() string {
+	return c.baseUrl
+}
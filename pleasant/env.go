@@ -0,0 +1,77 @@
+package pleasant
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// EnvNameCase is the case of the variable names returned by SanitizeEnvName
+type EnvNameCase string
+
+const (
+	EnvNameUpper EnvNameCase = "upper"
+	EnvNameLower EnvNameCase = "lower"
+	EnvNameKeep  EnvNameCase = "keep"
+)
+
+// EnvNameCases are the values accepted by ParseEnvNameCase
+var EnvNameCases = []string{string(EnvNameUpper), string(EnvNameLower), string(EnvNameKeep)}
+
+// ParseEnvNameCase parses the case of variable names, an empty string is EnvNameUpper
+func ParseEnvNameCase(s string) (EnvNameCase, error) {
+	if s == "" {
+		return EnvNameUpper, nil
+	}
+
+	if !slices.Contains(EnvNameCases, s) {
+		return "", fmt.Errorf("%w, must be one of: %v", ErrInvalidNameCase, strings.Join(EnvNameCases, ", "))
+	}
+
+	return EnvNameCase(s), nil
+}
+
+var envNameInvalid = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// SanitizeEnvName returns s as the name of an environment variable in the given case
+// Characters other than letters, digits and underscores are replaced by an underscore and names
+// starting with a digit are prefixed with one, e.g. 'Api-Key' becomes 'API_KEY' in upper case
+func SanitizeEnvName(s string, c EnvNameCase) string {
+	switch c {
+	case EnvNameUpper:
+		s = strings.ToUpper(s)
+	case EnvNameLower:
+		s = strings.ToLower(s)
+	}
+
+	name := envNameInvalid.ReplaceAllString(s, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+
+	return name
+}
+
+// EnvName returns s as the name of an environment variable in upper case, e.g. 'Api-Key' becomes 'API_KEY'
+func EnvName(s string) string {
+	return SanitizeEnvName(s, EnvNameUpper)
+}
+
+// ShellQuote quotes s for a POSIX shell
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// DotenvQuote quotes s for a .env file
+// Values are single-quoted, so they are taken literally, unless they contain a single quote or a line break.
+// Those are double-quoted with backslash escapes, '$' is escaped as well so variables are not expanded
+func DotenvQuote(s string) string {
+	if !strings.ContainsAny(s, "'\r\n") {
+		return "'" + s + "'"
+	}
+
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\r", `\r`, "\n", `\n`)
+
+	return `"` + r.Replace(s) + `"`
+}
@@ -0,0 +1,116 @@
+package pleasant
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSanitizeEnvName(t *testing.T) {
+	tests := []struct {
+		name string
+		c    EnvNameCase
+		want string
+	}{
+		{name: "Api-Key", c: EnvNameUpper, want: "API_KEY"},
+		{name: "Api-Key", c: EnvNameLower, want: "api_key"},
+		{name: "Api-Key", c: EnvNameKeep, want: "Api_Key"},
+		{name: "DB_PASS", c: EnvNameUpper, want: "DB_PASS"},
+		{name: "my entry (prod)", c: EnvNameUpper, want: "MY_ENTRY_PROD_"},
+		{name: "a--b..c", c: EnvNameUpper, want: "A_B_C"},
+		{name: "1password", c: EnvNameUpper, want: "_1PASSWORD"},
+		{name: "", c: EnvNameUpper, want: "_"},
+		{name: "ünïcode", c: EnvNameUpper, want: "_N_CODE"},
+		{name: "https://app/admin", c: EnvNameUpper, want: "HTTPS_APP_ADMIN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.c)+"/"+tt.name, func(t *testing.T) {
+			if got := SanitizeEnvName(tt.name, tt.c); got != tt.want {
+				t.Errorf("SanitizeEnvName(%q, %v) = %q, want %q", tt.name, tt.c, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	if got, want := EnvName("CustomUserFields_Api-Key"), "CUSTOMUSERFIELDS_API_KEY"; got != want {
+		t.Errorf("EnvName() = %q, want %q", got, want)
+	}
+}
+
+func TestParseEnvNameCase(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    EnvNameCase
+		wantErr error
+	}{
+		{s: "", want: EnvNameUpper},
+		{s: "upper", want: EnvNameUpper},
+		{s: "lower", want: EnvNameLower},
+		{s: "keep", want: EnvNameKeep},
+		{s: "UPPER", wantErr: ErrInvalidNameCase},
+		{s: "camel", wantErr: ErrInvalidNameCase},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseEnvNameCase(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseEnvNameCase(%q) error = %v, want %v", tt.s, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseEnvNameCase(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "secret", want: `'secret'`},
+		{s: "", want: `''`},
+		{s: "it's", want: `'it'\''s'`},
+		{s: "''", want: `''\'''\'''`},
+		{s: `$HOME "quoted" \n`, want: `'$HOME "quoted" \n'`},
+		{s: "`date`; rm -rf /", want: "'`date`; rm -rf /'"},
+		{s: "line1\nline2", want: "'line1\nline2'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := ShellQuote(tt.s); got != tt.want {
+				t.Errorf("ShellQuote(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDotenvQuote(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "secret", want: `'secret'`},
+		{s: "", want: `''`},
+		{s: `$HOME "quoted" \n`, want: `'$HOME "quoted" \n'`},
+		{s: "it's", want: `"it's"`},
+		{s: `it's "quoted" \ `, want: `"it's \"quoted\" \\ "`},
+		{s: "line1\nline2", want: `"line1\nline2"`},
+		{s: "crlf\r\n", want: `"crlf\r\n"`},
+		{s: "$VAR", want: `'$VAR'`},
+		{s: "it's $VAR and ${VAR}", want: `"it's \$VAR and \${VAR}"`},
+		{s: "$(cmd)\n$", want: `"\$(cmd)\n\$"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := DotenvQuote(tt.s); got != tt.want {
+				t.Errorf("DotenvQuote(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
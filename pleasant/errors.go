@@ -1,41 +1,241 @@
 package pleasant
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"net"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 )
 
 var (
-	ErrPrereqNotMet        = errors.New("error: not all prerequisites met")
-	ErrNotFound            = errors.New("error: the requested resource was not found")
-	ErrBadRequest          = errors.New("error: bad request")
-	ErrUnauthorized        = errors.New("error: unauthorized, please log in again")
-	ErrInvalidCredentials  = errors.New("error: invalid or incomplete credentials")
-	ErrPathStartIncorrect  = errors.New("error: path must start with 'Root/'")
-	ErrInvalidResourceType = errors.New("error: invalid resource type, must be 'entry' or 'folder'")
-	ErrNoResult            = errors.New("error: no matching entries or folders")
-	ErrParentNotFound      = errors.New("error: parent folder not found")
-	ErrAmbiguousResult     = errors.New("error: ambiguous result, multiple matching entries or folders")
-	ErrLastPathComp        = errors.New("error: last path component is empty")
-	ErrDuplicateEntry      = errors.New("error: duplicate entry found, skipping creation")
-	ErrDuplicateFolder     = errors.New("error: duplicate folder found, skipping creation")
-	ErrArchiveNotEnabled   = errors.New("error: entry/folder/accessrowid does not exist or archiving is possibly disabled")
+	ErrPrereqNotMet         = errors.New("error: not all prerequisites met")
+	ErrNotFound             = errors.New("error: the requested resource was not found")
+	ErrBadRequest           = errors.New("error: bad request")
+	ErrValidation           = errors.New("error: validation failed")
+	ErrUnauthorized         = errors.New("error: unauthorized, please log in again")
+	ErrForbidden            = errors.New("error: forbidden, insufficient permissions")
+	ErrInvalidCredentials   = errors.New("error: invalid or incomplete credentials")
+	ErrOtpRequired          = errors.New("error: a one-time password is required for two-factor authentication")
+	ErrPathStartIncorrect   = errors.New("error: path must start with 'Root/'")
+	ErrInvalidResourceType  = errors.New("error: invalid resource type, must be 'entry' or 'folder'")
+	ErrNoResult             = errors.New("error: no matching entries or folders")
+	ErrParentNotFound       = errors.New("error: parent folder not found")
+	ErrAmbiguousResult      = errors.New("error: ambiguous result, multiple matching entries or folders")
+	ErrLastPathComp         = errors.New("error: last path component is empty")
+	ErrNameMismatch         = errors.New("error: name from path and data do not match")
+	ErrDuplicateEntry       = errors.New("error: duplicate entry found, skipping creation")
+	ErrDuplicateFolder      = errors.New("error: duplicate folder found, skipping creation")
+	ErrProfileNotFound      = errors.New("error: profile does not exist")
+	ErrProfileExists        = errors.New("error: profile already exists")
+	ErrProfileNameRequired  = errors.New("error: a profile name or --none is required")
+	ErrFilePermissions      = errors.New("error: file permissions are too open, it must only be accessible by the owner")
+	ErrTokenEncrypted       = errors.New("error: token file is encrypted, set PLEASANT_TOKEN_PASSPHRASE or configure a token key file")
+	ErrTokenDecrypt         = errors.New("error: unable to decrypt token file, wrong passphrase or key file")
+	ErrInvalidResolver      = errors.New("error: invalid path resolver, must be 'search' or 'tree'")
+	ErrInvalidSortKey       = errors.New("error: invalid sort key")
+	ErrDestinationExists    = errors.New("error: destination already exists, use --force to overwrite it")
+	ErrDestinationNoFolder  = errors.New("error: destination must be an existing folder")
+	ErrMoveIntoItself       = errors.New("error: a folder cannot be moved into itself or one of its subfolders")
+	ErrRecursiveRequired    = errors.New("error: source is a folder, use -r to copy folders")
+	ErrAttachmentName       = errors.New("error: a file name is required for an attachment read from stdin, use --name")
+	ErrAttachmentNoFileName = errors.New("error: the attachment has no file name, use --file")
+	ErrNoData               = errors.New("error: no data supplied")
+	ErrInvalidOutput        = errors.New("error: invalid output format")
+	ErrInvalidJsonPath      = errors.New("error: invalid JSONPath template")
+	ErrFieldNotFound        = errors.New("error: entry has no such field")
+	ErrInvalidReference     = errors.New("error: invalid secret reference")
+	ErrInvalidNameCase      = errors.New("error: invalid name case")
+	ErrInvalidExportFormat  = errors.New("error: invalid export format")
+	ErrDuplicateVariable    = errors.New("error: more than one entry results in the same variable name")
+	ErrInvalidConfigValue   = errors.New("error: invalid config value")
+	ErrArchiveNotEnabled    = errors.New("error: entry/folder/accessrowid does not exist or archiving is possibly disabled")
 )
 
-func generateError(res *http.Response) error {
-	defer res.Body.Close()
+// Exit codes of pleasant-cli per failure class
+const (
+	ExitCodeOK           = 0
+	ExitCodeError        = 1
+	ExitCodePrereqNotMet = 2
+	ExitCodeNotFound     = 3
+	ExitCodeUnauthorized = 4
+	ExitCodeAmbiguous    = 5
+	ExitCodeDuplicate    = 6
+	ExitCodeValidation   = 7
+	ExitCodeNetwork      = 8
+)
 
-	body, _ := decodeBody(res.Body)
+// ExitCode returns the exit code for the failure class of err
+func ExitCode(err error) int {
+	// Only errors of requests count as network errors, syscall.Errno also implements net.Error
+	var urlErr *url.Error
+	var opErr *net.OpError
+
+	switch {
+	case err == nil:
+		return ExitCodeOK
+	case errors.Is(err, ErrPrereqNotMet), errors.Is(err, ErrFilePermissions), errors.Is(err, ErrTokenEncrypted), errors.Is(err, ErrTokenDecrypt):
+		return ExitCodePrereqNotMet
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrParentNotFound), errors.Is(err, ErrNoResult), errors.Is(err, ErrProfileNotFound), errors.Is(err, ErrFieldNotFound):
+		return ExitCodeNotFound
+	case errors.Is(err, ErrUnauthorized), errors.Is(err, ErrForbidden), errors.Is(err, ErrInvalidCredentials), errors.Is(err, ErrOtpRequired):
+		return ExitCodeUnauthorized
+	case errors.Is(err, ErrAmbiguousResult):
+		return ExitCodeAmbiguous
+	case errors.Is(err, ErrDuplicateEntry), errors.Is(err, ErrDuplicateFolder), errors.Is(err, ErrDestinationExists), errors.Is(err, ErrDuplicateVariable):
+		return ExitCodeDuplicate
+	case errors.Is(err, ErrBadRequest), errors.Is(err, ErrPathStartIncorrect), errors.Is(err, ErrInvalidResourceType), errors.Is(err, ErrLastPathComp), errors.Is(err, ErrNameMismatch), errors.Is(err, ErrInvalidResolver), errors.Is(err, ErrInvalidSortKey), errors.Is(err, ErrDestinationNoFolder), errors.Is(err, ErrMoveIntoItself), errors.Is(err, ErrRecursiveRequired), errors.Is(err, ErrAttachmentName), errors.Is(err, ErrAttachmentNoFileName), errors.Is(err, ErrNoData), errors.Is(err, ErrInvalidOutput), errors.Is(err, ErrInvalidJsonPath), errors.Is(err, ErrInvalidReference), errors.Is(err, ErrInvalidNameCase), errors.Is(err, ErrInvalidExportFormat), errors.Is(err, ErrInvalidConfigValue), errors.Is(err, ErrProfileNameRequired):
+		return ExitCodeValidation
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &urlErr), errors.As(err, &opErr):
+		return ExitCodeNetwork
+	default:
+		return ExitCodeError
+	}
+}
+
+// OtpRequiredError is returned when the server requires a one-time password to log in
+// It matches ErrOtpRequired with errors.Is
+type OtpRequiredError struct {
+	// Provider is the two-factor authentication provider that the one-time password must be sent for
+	Provider string
+}
+
+func (e *OtpRequiredError) Error() string {
+	return fmt.Sprintf("%v (provider: %v)", ErrOtpRequired, e.Provider)
+}
+
+func (e *OtpRequiredError) Is(target error) bool {
+	return target == ErrOtpRequired
+}
+
+// AmbiguousPathError is returned when a path matches more than one entry or folder
+// It matches ErrAmbiguousResult with errors.Is
+type AmbiguousPathError struct {
+	Path string
+	// Candidates holds the ids of all entries or folders that match the path
+	Candidates []string
+}
+
+func (e *AmbiguousPathError) Error() string {
+	return fmt.Sprintf("%v: '%v' matches %v", ErrAmbiguousResult, e.Path, strings.Join(e.Candidates, ", "))
+}
+
+func (e *AmbiguousPathError) Is(target error) bool {
+	return target == ErrAmbiguousResult
+}
+
+// APIError is returned when Pleasant Password Server responds with an unexpected status code
+// It matches the sentinel error for its status code with errors.Is, e.g. ErrNotFound for a 404
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	// Message is the error message returned by the server, if any
+	Message string
+	// ValidationErrors holds the validation messages per field, if the server rejected the input
+	ValidationErrors map[string][]string
+}
+
+// apiErrorBody covers the error formats returned by the REST API and the OAuth2 token endpoint
+type apiErrorBody struct {
+	Message          string
+	ExceptionMessage string
+	ModelState       map[string][]string
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("HTTP %v %v on %v %v", e.StatusCode, http.StatusText(e.StatusCode), e.Method, e.Path)
+
+	if s := e.sentinel(); s != nil {
+		msg = s.Error() + " (" + msg + ")"
+	} else {
+		msg = "error: " + msg
+	}
 
-	switch res.StatusCode {
+	if e.Message != "" {
+		msg = msg + ": " + e.Message
+	}
+
+	for _, field := range slices.Sorted(maps.Keys(e.ValidationErrors)) {
+		msg = msg + fmt.Sprintf("\n  %v: %v", field, strings.Join(e.ValidationErrors[field], ", "))
+	}
+
+	return msg
+}
+
+// Is reports whether target is the sentinel error for the status code and message of e
+// Every 400 response also matches ErrBadRequest
+func (e *APIError) Is(target error) bool {
+	if e.StatusCode == http.StatusBadRequest && target == ErrBadRequest {
+		return true
+	}
+
+	return target == e.sentinel()
+}
+
+// sentinel returns the most specific sentinel error for e, or nil if there is none
+func (e *APIError) sentinel() error {
+	switch e.StatusCode {
 	case http.StatusNotFound:
 		return ErrNotFound
-	case http.StatusBadRequest:
-		return ErrBadRequest
 	case http.StatusUnauthorized:
 		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusBadRequest:
+		switch {
+		case len(e.ValidationErrors) > 0:
+			return ErrValidation
+		case e.Method == http.MethodDelete && (e.Message == "" || strings.Contains(strings.ToLower(e.Message), "archiv")):
+			// The server does not always explain why a deletion was refused
+			return ErrArchiveNotEnabled
+		default:
+			return ErrBadRequest
+		}
 	default:
-		return fmt.Errorf("error: HTTP %v %v: %v", res.StatusCode, http.StatusText(res.StatusCode), body)
+		return nil
 	}
 }
+
+func generateError(res *http.Response) error {
+	defer res.Body.Close()
+
+	body, _ := decodeBody(res.Body)
+
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+	}
+
+	if res.Request != nil {
+		apiErr.Method = res.Request.Method
+		apiErr.Path = res.Request.URL.Path
+	}
+
+	eb := &apiErrorBody{}
+
+	if err := json.Unmarshal([]byte(body), eb); err == nil {
+		switch {
+		case eb.ErrorDescription != "":
+			apiErr.Message = eb.ErrorDescription
+		case eb.Error != "":
+			apiErr.Message = eb.Error
+		case eb.ExceptionMessage != "":
+			apiErr.Message = eb.ExceptionMessage
+		default:
+			apiErr.Message = eb.Message
+		}
+
+		apiErr.ValidationErrors = eb.ModelState
+	} else {
+		apiErr.Message = strings.TrimSpace(Unescape(TrimDoubleQuotes(body)))
+	}
+
+	return apiErr
+}
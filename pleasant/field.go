@@ -0,0 +1,133 @@
+package pleasant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IsPasswordField reports whether field selects the password of an entry
+// The password is not part of an entry returned by the server and must be requested separately
+func IsPasswordField(field string) bool {
+	return strings.EqualFold(field, "Password")
+}
+
+// EntryField returns the value of a field of an entry as text
+// Field names are case-insensitive, custom fields are selected with 'CustomUserFields.<name>' or
+// 'CustomApplicationFields.<name>'. Tags and attachments are returned as a comma-separated list of names
+func EntryField(e *Entry, field string) (string, error) {
+	name, key, isCustom := strings.Cut(field, ".")
+
+	if isCustom {
+		var fields map[string]string
+
+		switch strings.ToLower(name) {
+		case "customuserfields":
+			fields = e.CustomUserFields
+		case "customapplicationfields":
+			fields = e.CustomApplicationFields
+		default:
+			return "", fmt.Errorf("%w: %v", ErrFieldNotFound, field)
+		}
+
+		if v, ok := fields[key]; ok {
+			return v, nil
+		}
+
+		for k, v := range fields {
+			if strings.EqualFold(k, key) {
+				return v, nil
+			}
+		}
+
+		return "", fmt.Errorf("%w: %v", ErrFieldNotFound, field)
+	}
+
+	switch strings.ToLower(field) {
+	case "id":
+		return e.Id, nil
+	case "name":
+		return e.Name, nil
+	case "username":
+		return e.Username, nil
+	case "password":
+		return e.Password, nil
+	case "url":
+		return e.Url, nil
+	case "notes":
+		return e.Notes, nil
+	case "groupid":
+		return e.GroupId, nil
+	case "created":
+		return e.Created, nil
+	case "modified":
+		return e.Modified, nil
+	case "expires":
+		return e.Expires, nil
+	case "hasotpsecret":
+		return strconv.FormatBool(e.HasOtpSecret), nil
+	case "tags":
+		names := make([]string, len(e.Tags))
+		for i, t := range e.Tags {
+			names[i] = t.Name
+		}
+
+		return strings.Join(names, ","), nil
+	case "attachments":
+		names := make([]string, len(e.Attachments))
+		for i, a := range e.Attachments {
+			names[i] = a.FileName
+		}
+
+		return strings.Join(names, ","), nil
+	}
+
+	// Fields the model does not know about are returned as sent by the server
+	for k, raw := range e.Extra {
+		if !strings.EqualFold(k, field) {
+			continue
+		}
+
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			return s, nil
+		}
+
+		return string(raw), nil
+	}
+
+	return "", fmt.Errorf("%w: %v", ErrFieldNotFound, field)
+}
+
+// GetEntryFields returns the values of fields of the entry with the given id, in the order of fields
+// The password is only requested from the server if it is one of the fields
+func (c *Client) GetEntryFields(ctx context.Context, id string, fields []string) ([]string, error) {
+	e, err := c.GetEntry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fields {
+		if IsPasswordField(f) {
+			e.Password, err = c.GetEntryPassword(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+
+			break
+		}
+	}
+
+	values := make([]string, len(fields))
+
+	for i, f := range fields {
+		values[i], err = EntryField(e, f)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
@@ -3,19 +3,21 @@ package pleasant
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
-	"github.com/spf13/viper"
 	"golang.org/x/term"
 	"gopkg.in/yaml.v3"
 )
@@ -30,7 +32,7 @@ func CheckPrerequisites(prereq ...*Prerequisite) bool {
 
 	for _, p := range prereq {
 		if !p.PrerequisiteMet {
-			fmt.Println(p.Message)
+			fmt.Fprintln(os.Stderr, p.Message)
 			eCount++
 		}
 	}
@@ -38,8 +40,24 @@ func CheckPrerequisites(prereq ...*Prerequisite) bool {
 	return eCount <= 0
 }
 
-func IsTokenValid() *Prerequisite {
-	b := time.Now().Unix() <= viper.GetInt64("bearertoken.expiresat")
+// IsTokenValid checks whether the stored token has not expired yet or can be refreshed
+// If credentials are set in the environment, a token can always be obtained on the fly
+func IsTokenValid(store TokenStore) *Prerequisite {
+	if _, _, ok := EnvCredentials(); ok {
+		return &Prerequisite{
+			PrerequisiteMet: true,
+		}
+	}
+
+	token, err := store.Load()
+	if err != nil {
+		return &Prerequisite{
+			Message:         err.Error(),
+			PrerequisiteMet: false,
+		}
+	}
+
+	b := token != nil && (time.Now().Unix() <= token.ExpiresAt || token.RefreshToken != "")
 
 	pr := &Prerequisite{
 		Message:         "Token is expired or not present. Please log in (again) with 'pleasant-cli login'.",
@@ -49,154 +67,250 @@ func IsTokenValid() *Prerequisite {
 	return pr
 }
 
-func IsServerUrlSet() *Prerequisite {
-	b := (viper.IsSet("serverurl") && viper.GetString("serverurl") != "")
+// EnvCredentials returns the username and password set in the environment
+// ok is only true if both are set
+func EnvCredentials() (username, password string, ok bool) {
+	username = os.Getenv(EnvUsername)
+	password = os.Getenv(EnvPassword)
 
-	pr := &Prerequisite{
-		Message:         "Server URL is not set. Please set it with 'pleasant-cli config serverurl <SERVER URL>'.",
-		PrerequisiteMet: b,
+	return username, password, username != "" && password != ""
+}
+
+// ReadPasswordStdin reads the password from the first line of stdin
+func ReadPasswordStdin() (string, error) {
+	r := bufio.NewReader(os.Stdin)
+
+	s, err := r.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
 	}
 
-	return pr
+	s = strings.TrimRight(s, "\r\n")
+	if s == "" {
+		return "", ErrInvalidCredentials
+	}
+
+	return s, nil
 }
 
-func StringPrompt(label string) string {
-	var s string
+// IsStdinTerminal reports whether stdin is a terminal that can be prompted on
+func IsStdinTerminal() bool {
+	return term.IsTerminal(int(syscall.Stdin))
+}
 
+// StringPrompt prompts for a line of input on stdin, an empty line is prompted for again
+// If stdin has no more input, an error wrapping io.EOF is returned
+func StringPrompt(label string) (string, error) {
 	r := bufio.NewReader(os.Stdin)
 
 	for {
 		fmt.Fprint(os.Stderr, label+" ")
 
-		s, _ = r.ReadString('\n')
+		s, err := r.ReadString('\n')
 
+		s = strings.TrimSpace(s)
 		if s != "" {
-			break
+			return s, nil
 		}
-	}
 
-	return strings.TrimSpace(s)
+		if err != nil {
+			return "", fmt.Errorf("no input for prompt '%v': %w", label, err)
+		}
+	}
 }
 
-func PasswordPrompt(label string) string {
-	var s string
-
+// PasswordPrompt prompts for a password on the terminal without echoing it, an empty password is prompted for again
+// An error is returned if stdin is not a terminal or has no more input
+func PasswordPrompt(label string) (string, error) {
 	for {
 		fmt.Fprint(os.Stderr, label+" ")
-		b, _ := term.ReadPassword(int(syscall.Stdin))
 
-		s = string(b)
+		b, err := term.ReadPassword(int(syscall.Stdin))
+		if err != nil {
+			return "", fmt.Errorf("no input for prompt '%v': %w", label, err)
+		}
 
-		if s != "" {
-			break
+		if len(b) > 0 {
+			return string(b), nil
 		}
 	}
-
-	return s
 }
 
-func WriteConfigFile(file, key string, value string) error {
+// ReadConfigFile reads the config file, a missing file results in an empty config
+func ReadConfigFile(file string) (*ConfigFile, error) {
 	c := &ConfigFile{}
 
 	b, err := os.ReadFile(file)
-	if err == nil {
-		err = yaml.Unmarshal(b, c)
-		if err != nil {
-			return err
-		}
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	} else if err != nil {
+		return nil, err
 	}
 
-	v := reflect.ValueOf(c).Elem()
-	fv := v.FieldByName(key)
+	err = yaml.Unmarshal(b, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
 
-	if i, err := strconv.Atoi(value); err != nil {
+func writeConfigFile(file string, c *ConfigFile) error {
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	return WritePrivateFile(file, b)
+}
+
+// setField sets the field key of the struct pointed to by target to value
+// The value is parsed according to the type of the field, numbers must not be negative
+func setField(target any, key, value string) error {
+	fv := reflect.ValueOf(target).Elem().FieldByName(key)
+
+	switch fv.Kind() {
+	case reflect.String:
 		fv.SetString(value)
-	} else {
+	case reflect.Int:
+		i, err := strconv.Atoi(value)
+		if err != nil || i < 0 {
+			return fmt.Errorf("%w: %v must be a non-negative whole number, got '%v'", ErrInvalidConfigValue, strings.ToLower(key), value)
+		}
+
 		fv.SetInt(int64(i))
+	default:
+		return fmt.Errorf("%w: unknown setting %v", ErrInvalidConfigValue, strings.ToLower(key))
 	}
 
-	b, err = yaml.Marshal(c)
+	return nil
+}
+
+func WriteConfigFile(file, key string, value string) error {
+	c, err := ReadConfigFile(file)
 	if err != nil {
 		return err
 	}
 
-	err = os.WriteFile(file, b, 0666)
+	err = setField(c, key, value)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return writeConfigFile(file, c)
 }
 
-func WriteTokenFile(file, accessToken string, expiresAt int64) error {
-	t := &TokenFile{
-		Token: &Token{
-			AccessToken: accessToken,
-			ExpiresAt:   expiresAt,
-		},
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseUrl+path, body)
+	if err != nil {
+		return nil, err
 	}
 
-	b, err := yaml.Marshal(t)
+	bearerToken, err := c.tokenSource.Token(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = os.WriteFile(file, b, 0666)
-	if err != nil {
-		return err
+	if bearerToken != "" {
+		req.Header.Add("Authorization", "Bearer "+bearerToken)
 	}
 
-	return nil
+	return req, nil
 }
 
-func LoadConfig() (string, string) {
-	return viper.GetString("serverurl"), viper.GetString("bearertoken.accesstoken")
+func (c *Client) newJsonRequest(ctx context.Context, method, path, jsonString string) (*http.Request, error) {
+	payload := []byte(jsonString)
+
+	req, err := c.newRequest(ctx, method, path, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+	return req, nil
 }
 
-func newHttpClient(to int) *http.Client {
-	// Set timeout to 20 if no timeout is specified
-	if to == 0 {
-		to = 20
+// requestFunc builds a new request for an attempt with the given context
+type requestFunc func(ctx context.Context) (*http.Request, error)
+
+// do performs a single attempt of the request and returns the response body if the status code is one of okCodes
+// If the server responds with 401 and the token source can be refreshed, the token is refreshed and the request is sent once more
+// The response is returned alongside an error if one was received, so the caller can decide whether to retry
+func (c *Client) do(ctx context.Context, newReq requestFunc, okCodes ...int) ([]byte, *http.Response, error) {
+	b, res, err := c.doOnce(ctx, newReq, okCodes...)
+
+	rts, ok := c.tokenSource.(RefreshableTokenSource)
+	if !ok || res == nil || res.StatusCode != http.StatusUnauthorized || res.Request.Header.Get("Authorization") == "" {
+		return b, res, err
 	}
 
-	return &http.Client{
-		Timeout: time.Duration(to) * time.Second,
+	_, err = rts.Refresh(ctx)
+	if err != nil {
+		return nil, res, err
 	}
+
+	return c.doOnce(ctx, newReq, okCodes...)
 }
 
-func getRequest(baseUrl, path, bearerToken string) (*http.Response, error) {
-	method := "GET"
+// doOnce sends the request built by newReq without any retries
+// The client timeout is applied as a deadline to the request, the body is read before the deadline is cancelled
+func (c *Client) doOnce(ctx context.Context, newReq requestFunc, okCodes ...int) ([]byte, *http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
 
-	req, err := http.NewRequest(method, baseUrl+path, nil)
+	req, err := newReq(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	if bearerToken != "" {
-		req.Header.Add("Authorization", "Bearer "+bearerToken)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	to := viper.GetInt("timeout")
+	defer res.Body.Close()
 
-	client := newHttpClient(to)
+	if !slices.Contains(okCodes, res.StatusCode) {
+		return nil, res, generateError(res)
+	}
 
-	res, err := client.Do(req)
+	b, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
-	} else if res.StatusCode != http.StatusOK {
-		return nil, generateError(res)
+		return nil, res, err
 	}
 
-	return res, nil
+	return b, res, nil
 }
 
-func decodeJsonBody(body io.ReadCloser, target any) error {
-	err := json.NewDecoder(body).Decode(target)
-	if err != nil {
-		return err
+// doRetry performs the request and retries it on transient errors according to the retry policy of the client
+// It must only be used for idempotent requests
+func (c *Client) doRetry(ctx context.Context, newReq requestFunc, okCodes ...int) ([]byte, error) {
+	for retry := 1; ; retry++ {
+		b, res, err := c.do(ctx, newReq, okCodes...)
+		if err == nil || retry > c.retryPolicy.MaxRetries || !isTransient(ctx, res, err) {
+			return b, err
+		}
+
+		t := time.NewTimer(c.retryPolicy.backoff(retry, parseRetryAfter(res)))
+
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
 	}
+}
 
-	return nil
+func (c *Client) getRequest(ctx context.Context, path string) ([]byte, error) {
+	method := "GET"
+
+	newReq := func(ctx context.Context) (*http.Request, error) {
+		return c.newRequest(ctx, method, path, nil)
+	}
+
+	return c.doRetry(ctx, newReq, http.StatusOK)
 }
 
 func decodeBody(body io.ReadCloser) (string, error) {
@@ -210,132 +324,226 @@ func decodeBody(body io.ReadCloser) (string, error) {
 	return buf.String(), nil
 }
 
-func postRequestForm(baseUrl, path string, urlValues url.Values) (*http.Response, error) {
+// postRequestForm posts the form with the additional headers in header, which may be nil
+// The response is returned alongside an error, so the caller can inspect its headers
+func (c *Client) postRequestForm(ctx context.Context, path string, urlValues url.Values, header http.Header) ([]byte, *http.Response, error) {
 	method := "POST"
 
-	payload := strings.NewReader(urlValues.Encode())
+	newReq := func(ctx context.Context) (*http.Request, error) {
+		payload := strings.NewReader(urlValues.Encode())
 
-	req, err := http.NewRequest(method, baseUrl+path, payload)
-	if err != nil {
-		return nil, err
-	}
+		req, err := http.NewRequestWithContext(ctx, method, c.baseUrl+path, payload)
+		if err != nil {
+			return nil, err
+		}
 
-	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+		for k, v := range header {
+			req.Header[k] = v
+		}
 
-	to := viper.GetInt("timeout")
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 
-	client := newHttpClient(to)
+		return req, nil
+	}
 
-	res, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	} else if res.StatusCode != http.StatusOK {
-		return nil, generateError(res)
+	return c.do(ctx, newReq, http.StatusOK)
+}
+
+func (c *Client) postRequestJsonString(ctx context.Context, path, jsonString string) ([]byte, error) {
+	method := "POST"
+
+	newReq := func(ctx context.Context) (*http.Request, error) {
+		return c.newJsonRequest(ctx, method, path, jsonString)
 	}
 
-	return res, nil
+	b, _, err := c.do(ctx, newReq, http.StatusOK, http.StatusNoContent)
+
+	return b, err
 }
 
-func postRequestJsonString(baseUrl, path, jsonString, bearerToken string) (*http.Response, error) {
+// searchRequestJsonString posts a search query, unlike other POST requests it is idempotent and can be retried
+func (c *Client) searchRequestJsonString(ctx context.Context, jsonString string) ([]byte, error) {
 	method := "POST"
 
-	payload := []byte(jsonString)
+	newReq := func(ctx context.Context) (*http.Request, error) {
+		return c.newJsonRequest(ctx, method, PathSearch, jsonString)
+	}
 
-	req, err := http.NewRequest(method, baseUrl+path, bytes.NewBuffer(payload))
-	if err != nil {
-		return nil, err
+	return c.doRetry(ctx, newReq, http.StatusOK)
+}
+
+func (c *Client) patchRequestJsonString(ctx context.Context, path, jsonString string) ([]byte, error) {
+	method := "PATCH"
+
+	newReq := func(ctx context.Context) (*http.Request, error) {
+		return c.newJsonRequest(ctx, method, path, jsonString)
 	}
 
-	if bearerToken != "" {
-		req.Header.Add("Authorization", "Bearer "+bearerToken)
+	return c.doRetry(ctx, newReq, http.StatusOK, http.StatusNoContent)
+}
+
+func (c *Client) deleteRequestJsonString(ctx context.Context, path, jsonString string) ([]byte, error) {
+	method := "DELETE"
+
+	newReq := func(ctx context.Context) (*http.Request, error) {
+		return c.newJsonRequest(ctx, method, path, jsonString)
 	}
 
-	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	b, _, err := c.do(ctx, newReq, http.StatusOK, http.StatusNoContent)
 
-	to := viper.GetInt("timeout")
+	return b, err
+}
 
-	client := newHttpClient(to)
+func unmarshalSearchResponse(jsonString string) (*SearchOutput, error) {
+	sr := &SearchOutput{}
 
-	res, err := client.Do(req)
+	err := json.Unmarshal([]byte(jsonString), sr)
 	if err != nil {
 		return nil, err
-	} else if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
-		return nil, generateError(res)
 	}
-	return res, nil
+
+	return sr, nil
 }
 
-func patchRequestJsonString(baseUrl, path, jsonString, bearerToken string) (*http.Response, error) {
-	method := "PATCH"
+func (e *Entry) UnmarshalJSON(b []byte) error {
+	type entry Entry
 
-	payload := []byte(jsonString)
-
-	req, err := http.NewRequest(method, baseUrl+path, bytes.NewBuffer(payload))
+	extra, present, err := unmarshalWithExtra(b, (*entry)(e))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if bearerToken != "" {
-		req.Header.Add("Authorization", "Bearer "+bearerToken)
-	}
+	e.Extra, e.present = extra, present
 
-	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	return nil
+}
 
-	to := viper.GetInt("timeout")
+func (e Entry) MarshalJSON() ([]byte, error) {
+	type entry Entry
+	return marshalWithExtra(entry(e), e.Extra, e.present)
+}
 
-	client := newHttpClient(to)
+func (f *Folder) UnmarshalJSON(b []byte) error {
+	type folder Folder
 
-	res, err := client.Do(req)
+	extra, present, err := unmarshalWithExtra(b, (*folder)(f))
 	if err != nil {
-		return nil, err
-	} else if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
-		return nil, generateError(res)
+		return err
 	}
 
-	return res, nil
+	f.Extra, f.present = extra, present
+
+	return nil
 }
 
-func deleteRequestJsonString(baseUrl, path, jsonString, bearerToken string) (*http.Response, error) {
-	method := "DELETE"
+func (f Folder) MarshalJSON() ([]byte, error) {
+	type folder Folder
+	return marshalWithExtra(folder(f), f.Extra, f.present)
+}
 
-	payload := []byte(jsonString)
+// jsonFieldName returns the name of a struct field in JSON, or an empty string if it is not marshalled
+func jsonFieldName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
 
-	req, err := http.NewRequest(method, baseUrl+path, bytes.NewBuffer(payload))
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+
+	return name
+}
+
+// unmarshalWithExtra unmarshals b into the struct v and returns the fields of b that v does not have
+// and the fields of b that v has, keyed by their name in v
+func unmarshalWithExtra(b []byte, v any) (map[string]json.RawMessage, map[string]json.RawMessage, error) {
+	err := json.Unmarshal(b, v)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	if bearerToken != "" {
-		req.Header.Add("Authorization", "Bearer "+bearerToken)
+	fields := map[string]json.RawMessage{}
+	if json.Unmarshal(b, &fields) != nil {
+		// Not an object, e.g. null
+		return nil, nil, nil
 	}
 
-	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	present := map[string]json.RawMessage{}
 
-	to := viper.GetInt("timeout")
+	t := reflect.TypeOf(v).Elem()
+	for i := range t.NumField() {
+		name := jsonFieldName(t.Field(i))
+		if name == "" {
+			continue
+		}
 
-	client := newHttpClient(to)
+		for k, raw := range fields {
+			// Field names are matched case-insensitively, like encoding/json does
+			if strings.EqualFold(k, name) {
+				present[name] = raw
+				delete(fields, k)
+			}
+		}
+	}
 
-	res, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	} else if res.StatusCode == 400 {
-		return nil, ErrArchiveNotEnabled
-	} else if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
-		return nil, generateError(res)
+	if len(fields) == 0 {
+		fields = nil
 	}
 
-	return res, nil
+	if len(present) == 0 {
+		present = nil
+	}
+
+	return fields, present, nil
 }
 
-func unmarshalSearchResponse(jsonString string) (*SearchOutput, error) {
-	sr := &SearchOutput{}
+// marshalWithExtra marshals the struct v and adds the fields in extra
+// Fields of v that are in present are marshalled even if they are empty, a null in present is kept as null
+func marshalWithExtra(v any, extra, present map[string]json.RawMessage) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || (len(extra) == 0 && len(present) == 0) {
+		return b, err
+	}
 
-	err := json.Unmarshal([]byte(jsonString), sr)
+	fields := map[string]json.RawMessage{}
+
+	err = json.Unmarshal(b, &fields)
 	if err != nil {
 		return nil, err
 	}
 
-	return sr, nil
+	rv := reflect.ValueOf(v)
+	for i := range rv.NumField() {
+		name := jsonFieldName(rv.Type().Field(i))
+
+		raw, ok := present[name]
+		if _, marshalled := fields[name]; !ok || marshalled {
+			continue
+		}
+
+		// The field was omitted because it is empty
+		if string(raw) != "null" {
+			raw, err = json.Marshal(rv.Field(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		fields[name] = raw
+	}
+
+	for k, raw := range extra {
+		if _, ok := fields[k]; !ok {
+			fields[k] = raw
+		}
+	}
+
+	return json.Marshal(fields)
 }
 
 func UnmarshalEntry(jsonString string) (*Entry, error) {
@@ -352,7 +560,7 @@ func UnmarshalEntry(jsonString string) (*Entry, error) {
 func MarshalEntry(entry *Entry) (string, error) {
 	b, err := json.Marshal(entry)
 	if err != nil {
-		return "", nil
+		return "", err
 	}
 
 	return string(b), nil
@@ -372,7 +580,7 @@ func UnmarshalFolder(jsonString string) (*Folder, error) {
 func MarshalFolder(folder *Folder) (string, error) {
 	b, err := json.Marshal(folder)
 	if err != nil {
-		return "", nil
+		return "", err
 	}
 
 	return string(b), nil
@@ -389,6 +597,31 @@ func UnmarshalFolderOutput(jsonString string) (*FolderOutput, error) {
 	return fo, nil
 }
 
+func unmarshalString(jsonString string) (string, error) {
+	var str string
+
+	err := json.Unmarshal([]byte(jsonString), &str)
+	if err != nil {
+		return "", err
+	}
+
+	return str, nil
+}
+
+func marshalDeleteRequest(action DeleteAction) (string, error) {
+	dr := &DeleteRequest{
+		Action:  action,
+		Comment: "Archived/deleted by Pleasant-CLI",
+	}
+
+	b, err := json.Marshal(dr)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
 func PrettyPrintJson(jsonString string) (string, error) {
 	var trgt interface{}
 
@@ -406,18 +639,10 @@ func PrettyPrintJson(jsonString string) (string, error) {
 }
 
 func PathAndNameMatching(resourcePath, name string) bool {
-	s := strings.Split(resourcePath, "/")
+	s := SplitPath(resourcePath)
 	return s[len(s)-1] == name
 }
 
-func parentPath(resourcePath string) string {
-	lastSlash := strings.LastIndex(resourcePath, "/")
-	if lastSlash == -1 {
-		return resourcePath
-	}
-	return resourcePath[:lastSlash]
-}
-
 func deduplicateStrSlice(strSlice []string) []string {
 	allKeys := make(map[string]bool)
 	list := []string{}
@@ -448,7 +673,16 @@ func Exit(msg ...any) {
 	os.Exit(0)
 }
 
+// ExitFatal prints msg to stderr and exits with the exit code of the first error in msg
+// If msg contains no error, the exit code is ExitCodeError
 func ExitFatal(msg ...any) {
-	fmt.Println(msg...)
-	os.Exit(1)
+	fmt.Fprintln(os.Stderr, msg...)
+
+	for _, m := range msg {
+		if err, ok := m.(error); ok {
+			os.Exit(ExitCode(err))
+		}
+	}
+
+	os.Exit(ExitCodeError)
 }
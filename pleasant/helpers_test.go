@@ -0,0 +1,124 @@
+package pleasant
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetField(t *testing.T) {
+	tests := []struct {
+		key     string
+		value   string
+		want    Profile
+		wantErr error
+	}{
+		{key: "ServerUrl", value: "https://pleasant.example.com", want: Profile{ServerUrl: "https://pleasant.example.com"}},
+		{key: "TokenKeyFile", value: "123", want: Profile{TokenKeyFile: "123"}},
+		{key: "Resolver", value: "0", want: Profile{Resolver: "0"}},
+		{key: "Retries", value: "3", want: Profile{Retries: 3}},
+		{key: "IndexTtl", value: "0", want: Profile{}},
+		{key: "Retries", value: "abc", wantErr: ErrInvalidConfigValue},
+		{key: "Timeout", value: "-1", wantErr: ErrInvalidConfigValue},
+		{key: "IndexTtl", value: "10m", wantErr: ErrInvalidConfigValue},
+		{key: "Unknown", value: "x", wantErr: ErrInvalidConfigValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			p := Profile{}
+
+			err := setField(&p, tt.key, tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("setField() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if p != tt.want {
+				t.Errorf("setField() = %+v, want %+v", p, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalEntryRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "empty values are kept",
+			input: `{"Name":"n","Notes":"","Url":"","Expires":null,"CustomUserFields":{},"Tags":[]}`,
+			want:  `{"CustomUserFields":{},"Expires":null,"Name":"n","Notes":"","Tags":[],"Url":""}`,
+		},
+		{
+			name:  "missing values are not added",
+			input: `{"Name":"n"}`,
+			want:  `{"Name":"n"}`,
+		},
+		{
+			name:  "unknown fields are kept",
+			input: `{"Name":"n","Notes":"","SomeNewField":[1]}`,
+			want:  `{"Name":"n","Notes":"","SomeNewField":[1]}`,
+		},
+		{
+			name:  "field names are matched case-insensitively",
+			input: `{"name":"n","notes":""}`,
+			want:  `{"Name":"n","Notes":""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := UnmarshalEntry(tt.input)
+			if err != nil {
+				t.Fatalf("UnmarshalEntry() error = %v", err)
+			}
+
+			got, err := MarshalEntry(e)
+			if err != nil {
+				t.Fatalf("MarshalEntry() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("MarshalEntry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalEntryClearedField(t *testing.T) {
+	e, err := UnmarshalEntry(`{"Name":"n","Notes":"old"}`)
+	if err != nil {
+		t.Fatalf("UnmarshalEntry() error = %v", err)
+	}
+
+	e.Notes = ""
+
+	got, err := MarshalEntry(e)
+	if err != nil {
+		t.Fatalf("MarshalEntry() error = %v", err)
+	}
+
+	if want := `{"Name":"n","Notes":""}`; got != want {
+		t.Errorf("MarshalEntry() = %v, want %v", got, want)
+	}
+}
+
+func TestMarshalFolderRoundTrip(t *testing.T) {
+	input := `{"Name":"f","Notes":"","CustomApplicationFields":{},"Tags":[]}`
+	want := `{"CustomApplicationFields":{},"Name":"f","Notes":"","Tags":[]}`
+
+	f, err := UnmarshalFolder(input)
+	if err != nil {
+		t.Fatalf("UnmarshalFolder() error = %v", err)
+	}
+
+	got, err := MarshalFolder(f)
+	if err != nil {
+		t.Fatalf("MarshalFolder() error = %v", err)
+	}
+
+	if got != want {
+		t.Errorf("MarshalFolder() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,402 @@
+package pleasant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// pathIndexVersion is increased when the format of the index changes, so older index files are rebuilt
+const pathIndexVersion = 1
+
+// PathIndex maps the paths of all folders and entries to their ids
+// It is built from the folder tree and cached in a file, so paths can be resolved without a search
+type PathIndex struct {
+	Version   int         `yaml:"version"`
+	ServerUrl string      `yaml:"serverurl"`
+	CreatedAt int64       `yaml:"createdat"`
+	Folders   []IndexItem `yaml:"folders"`
+	Entries   []IndexItem `yaml:"entries"`
+}
+
+// IndexItem is a folder or entry in the PathIndex
+// Path is the full path of the folder or entry with escaped names, e.g. 'Root/Folder1/Entry'
+type IndexItem struct {
+	Id   string `yaml:"id"`
+	Path string `yaml:"path"`
+}
+
+// pathIndexCache holds the location of the index file and the index once it has been loaded
+type pathIndexCache struct {
+	file  string
+	ttl   time.Duration
+	index *PathIndex
+}
+
+// WithPathIndex makes the client resolve paths with a local index stored in file
+// The index is rebuilt once it is older than ttl and updated by the client when it creates,
+// modifies or deletes an entry or folder
+func WithPathIndex(file string, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.index = &pathIndexCache{
+			file: file,
+			ttl:  ttl,
+		}
+	}
+}
+
+// BuildPathIndex builds a new PathIndex from the folder tree
+func (c *Client) BuildPathIndex(ctx context.Context) (*PathIndex, error) {
+	root, err := c.GetFolders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := &PathIndex{
+		Version:   pathIndexVersion,
+		ServerUrl: c.baseUrl,
+		CreatedAt: time.Now().Unix(),
+	}
+
+	idx.add(root, EscapePathSegment(root.Name))
+
+	return idx, nil
+}
+
+func (idx *PathIndex) add(folder *Folder, path string) {
+	idx.Folders = append(idx.Folders, IndexItem{Id: folder.Id, Path: path})
+
+	for _, e := range folder.Credentials {
+		idx.Entries = append(idx.Entries, IndexItem{Id: e.Id, Path: path + "/" + EscapePathSegment(e.Name)})
+	}
+
+	for i := range folder.Children {
+		idx.add(&folder.Children[i], path+"/"+EscapePathSegment(folder.Children[i].Name))
+	}
+}
+
+// lookup returns the ids of all folders or entries with the given path
+func (idx *PathIndex) lookup(resourcePath, resourceType string) []string {
+	items := idx.Entries
+	if resourceType == "folder" {
+		items = idx.Folders
+	}
+
+	ids := []string{}
+	for _, item := range items {
+		if item.Path == resourcePath {
+			ids = append(ids, item.Id)
+		}
+	}
+
+	return ids
+}
+
+// children returns the names of the entries and folders directly below the folder with the given path
+func (idx *PathIndex) children(folderPath string) ([]string, []string) {
+	entryNames := []string{}
+	for _, item := range idx.Entries {
+		if name, ok := childName(item.Path, folderPath); ok {
+			entryNames = append(entryNames, name)
+		}
+	}
+
+	folderNames := []string{}
+	for _, item := range idx.Folders {
+		if name, ok := childName(item.Path, folderPath); ok {
+			folderNames = append(folderNames, name)
+		}
+	}
+
+	return entryNames, folderNames
+}
+
+// childName returns the unescaped name of the item at itemPath if it is directly below folderPath
+func childName(itemPath, folderPath string) (string, bool) {
+	segments := SplitPath(itemPath)
+	if len(segments) < 2 || JoinPath(segments[:len(segments)-1]...) != folderPath {
+		return "", false
+	}
+
+	return segments[len(segments)-1], true
+}
+
+// pathIndex returns the path index, it is loaded from the index file or rebuilt if the file is missing or outdated
+func (c *Client) pathIndex(ctx context.Context) (*PathIndex, error) {
+	if idx := c.cachedPathIndex(); idx != nil {
+		return idx, nil
+	}
+
+	idx, err := c.BuildPathIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writePathIndex(c.index.file, idx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.index.index = idx
+
+	return idx, nil
+}
+
+// cachedPathIndex returns the loaded path index or the one in the index file, or nil if there is no valid index
+func (c *Client) cachedPathIndex() *PathIndex {
+	if c.index.index != nil {
+		return c.index.index
+	}
+
+	idx, err := readPathIndex(c.index.file)
+	if err != nil || idx.Version != pathIndexVersion || idx.ServerUrl != c.baseUrl || time.Since(time.Unix(idx.CreatedAt, 0)) >= c.index.ttl {
+		return nil
+	}
+
+	c.index.index = idx
+
+	return idx
+}
+
+// updatePathIndex applies a successful create (POST), modification (PATCH) or deletion (DELETE) of
+// an entry or folder to the path index. Requests for other resources, e.g. attachments, are ignored
+// If the change cannot be applied, the index is removed so it is rebuilt on the next lookup
+func (c *Client) updatePathIndex(method, path, jsonString, response string) {
+	if c.index == nil {
+		return
+	}
+
+	resourceType, id, ok := indexResource(path)
+	if !ok {
+		return
+	}
+
+	idx := c.cachedPathIndex()
+	if idx == nil {
+		// There is no valid index, it is built on the next lookup
+		return
+	}
+
+	var err error
+
+	if method == http.MethodPost {
+		id, err = unmarshalString(response)
+	}
+
+	if err == nil {
+		err = idx.update(method, resourceType, id, jsonString)
+	}
+
+	if err == nil {
+		err = writePathIndex(c.index.file, idx)
+	}
+
+	if err == nil {
+		return
+	}
+
+	c.index.index = nil
+
+	err = ClearPathIndex(c.index.file)
+	if err != nil {
+		c.warn(fmt.Errorf("unable to remove the outdated path index, it is used until it expires: %w", err))
+	}
+}
+
+// indexResource returns the resource type and id of an entries or folders path of the API
+// The id is empty for the collection itself, ok is false for other paths
+func indexResource(path string) (resourceType, id string, ok bool) {
+	for prefix, t := range map[string]string{PathEntry: "entry", PathFolders: "folder"} {
+		rest, found := strings.CutPrefix(path, prefix)
+		if !found {
+			continue
+		}
+
+		if rest == "" {
+			return t, "", true
+		}
+
+		id, found := strings.CutPrefix(rest, "/")
+		if found && id != "" && !strings.Contains(id, "/") {
+			return t, id, true
+		}
+	}
+
+	return "", "", false
+}
+
+// errNotIndexed is returned when a change refers to an entry or folder that is not in the path index
+var errNotIndexed = errors.New("error: entry or folder is not in the path index")
+
+// update applies the creation, modification or deletion of the entry or folder with the given id
+func (idx *PathIndex) update(method, resourceType, id, jsonString string) error {
+	if method == http.MethodDelete {
+		idx.remove(resourceType, id)
+		return nil
+	}
+
+	change := struct {
+		Name     string
+		GroupId  string
+		ParentId string
+	}{}
+
+	err := json.Unmarshal([]byte(jsonString), &change)
+	if err != nil {
+		return err
+	}
+
+	parentId := change.GroupId
+	if resourceType == "folder" {
+		parentId = change.ParentId
+	}
+
+	var oldPath string
+	var segments []string
+
+	if method == http.MethodPatch {
+		oldPath = idx.pathById(resourceType, id)
+		if oldPath == "" {
+			return errNotIndexed
+		}
+
+		segments = SplitPath(oldPath)
+	}
+
+	name := change.Name
+	if name == "" && len(segments) > 1 {
+		name = segments[len(segments)-1]
+	}
+
+	var parentPath string
+
+	switch {
+	case parentId != "":
+		parentPath = idx.pathById("folder", parentId)
+	case len(segments) > 1:
+		parentPath = JoinPath(segments[:len(segments)-1]...)
+	}
+
+	if name == "" || parentPath == "" {
+		return errNotIndexed
+	}
+
+	newPath := parentPath + "/" + EscapePathSegment(name)
+
+	if method == http.MethodPost {
+		if resourceType == "entry" {
+			idx.Entries = append(idx.Entries, IndexItem{Id: id, Path: newPath})
+		} else {
+			idx.Folders = append(idx.Folders, IndexItem{Id: id, Path: newPath})
+		}
+
+		return nil
+	}
+
+	if resourceType == "entry" {
+		idx.move(idx.Entries, oldPath, newPath, false)
+	} else {
+		// The contents of a folder move along with it
+		idx.move(idx.Folders, oldPath, newPath, true)
+		idx.move(idx.Entries, oldPath, newPath, true)
+	}
+
+	return nil
+}
+
+// pathById returns the path of the folder or entry with the given id, or an empty string if it is not indexed
+func (idx *PathIndex) pathById(resourceType, id string) string {
+	items := idx.Entries
+	if resourceType == "folder" {
+		items = idx.Folders
+	}
+
+	for _, item := range items {
+		if item.Id == id {
+			return item.Path
+		}
+	}
+
+	return ""
+}
+
+// move changes the path of the items at oldPath and, if descendants is set, of the items below it
+func (idx *PathIndex) move(items []IndexItem, oldPath, newPath string, descendants bool) {
+	for i := range items {
+		switch {
+		case items[i].Path == oldPath:
+			items[i].Path = newPath
+		case descendants && strings.HasPrefix(items[i].Path, oldPath+"/"):
+			items[i].Path = newPath + strings.TrimPrefix(items[i].Path, oldPath)
+		}
+	}
+}
+
+// remove removes the entry or folder with the given id, a folder is removed with its contents
+func (idx *PathIndex) remove(resourceType, id string) {
+	if resourceType == "entry" {
+		idx.Entries = slices.DeleteFunc(idx.Entries, func(item IndexItem) bool {
+			return item.Id == id
+		})
+
+		return
+	}
+
+	path := idx.pathById("folder", id)
+
+	inFolder := func(item IndexItem) bool {
+		return item.Id == id || (path != "" && strings.HasPrefix(item.Path, path+"/"))
+	}
+
+	idx.Folders = slices.DeleteFunc(idx.Folders, inFolder)
+	idx.Entries = slices.DeleteFunc(idx.Entries, inFolder)
+}
+
+// ClearPathIndex removes the index file, a missing file is not an error
+func ClearPathIndex(file string) error {
+	err := os.Remove(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+func readPathIndex(file string) (*PathIndex, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := &PathIndex{}
+
+	err = yaml.Unmarshal(b, idx)
+	if err != nil {
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+func writePathIndex(file string, idx *PathIndex) error {
+	err := os.MkdirAll(filepath.Dir(file), 0700)
+	if err != nil {
+		return err
+	}
+
+	b, err := yaml.Marshal(idx)
+	if err != nil {
+		return err
+	}
+
+	return WritePrivateFile(file, b)
+}
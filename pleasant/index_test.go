@@ -0,0 +1,134 @@
+package pleasant
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func testPathIndex() *PathIndex {
+	return &PathIndex{
+		Folders: []IndexItem{
+			{Id: "root", Path: "Root"},
+			{Id: "a", Path: "Root/A"},
+			{Id: "b", Path: "Root/A/B"},
+			{Id: "c", Path: "Root/C"},
+		},
+		Entries: []IndexItem{
+			{Id: "e1", Path: "Root/A/E1"},
+			{Id: "e2", Path: "Root/A/B/E2"},
+			{Id: "e3", Path: "Root/C/E3"},
+		},
+	}
+}
+
+func TestIndexResource(t *testing.T) {
+	tests := []struct {
+		path             string
+		wantType, wantId string
+		wantOk           bool
+	}{
+		{path: PathEntry, wantType: "entry", wantOk: true},
+		{path: PathEntry + "/e1", wantType: "entry", wantId: "e1", wantOk: true},
+		{path: PathFolders, wantType: "folder", wantOk: true},
+		{path: PathFolders + "/a", wantType: "folder", wantId: "a", wantOk: true},
+		{path: PathEntry + "/e1/attachments"},
+		{path: PathEntry + "/e1/attachments/x"},
+		{path: PathFolders + "/a/useraccess"},
+		{path: PathEntry + "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			gotType, gotId, gotOk := indexResource(tt.path)
+			if gotType != tt.wantType || gotId != tt.wantId || gotOk != tt.wantOk {
+				t.Errorf("indexResource(%q) = %q, %q, %v, want %q, %q, %v", tt.path, gotType, gotId, gotOk, tt.wantType, tt.wantId, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestPathIndexUpdate(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		resourceType string
+		id           string
+		json         string
+		wantFolders  []IndexItem
+		wantEntries  []IndexItem
+		wantErr      bool
+	}{
+		{
+			name: "create entry", method: http.MethodPost, resourceType: "entry", id: "e4",
+			json:        `{"Name":"x/y","GroupId":"b"}`,
+			wantFolders: testPathIndex().Folders,
+			wantEntries: append(testPathIndex().Entries, IndexItem{Id: "e4", Path: `Root/A/B/x\/y`}),
+		},
+		{
+			name: "create folder", method: http.MethodPost, resourceType: "folder", id: "d",
+			json:        `{"name":"D","parentId":"root"}`,
+			wantFolders: append(testPathIndex().Folders, IndexItem{Id: "d", Path: "Root/D"}),
+			wantEntries: testPathIndex().Entries,
+		},
+		{
+			name: "rename entry", method: http.MethodPatch, resourceType: "entry", id: "e1",
+			json:        `{"Name":"E9"}`,
+			wantFolders: testPathIndex().Folders,
+			wantEntries: []IndexItem{{Id: "e1", Path: "Root/A/E9"}, {Id: "e2", Path: "Root/A/B/E2"}, {Id: "e3", Path: "Root/C/E3"}},
+		},
+		{
+			name: "move folder with contents", method: http.MethodPatch, resourceType: "folder", id: "a",
+			json:        `{"ParentId":"c"}`,
+			wantFolders: []IndexItem{{Id: "root", Path: "Root"}, {Id: "a", Path: "Root/C/A"}, {Id: "b", Path: "Root/C/A/B"}, {Id: "c", Path: "Root/C"}},
+			wantEntries: []IndexItem{{Id: "e1", Path: "Root/C/A/E1"}, {Id: "e2", Path: "Root/C/A/B/E2"}, {Id: "e3", Path: "Root/C/E3"}},
+		},
+		{
+			name: "patch other fields", method: http.MethodPatch, resourceType: "entry", id: "e3",
+			json:        `{"Password":"secret"}`,
+			wantFolders: testPathIndex().Folders,
+			wantEntries: testPathIndex().Entries,
+		},
+		{
+			name: "delete entry", method: http.MethodDelete, resourceType: "entry", id: "e2",
+			wantFolders: testPathIndex().Folders,
+			wantEntries: []IndexItem{{Id: "e1", Path: "Root/A/E1"}, {Id: "e3", Path: "Root/C/E3"}},
+		},
+		{
+			name: "delete folder with contents", method: http.MethodDelete, resourceType: "folder", id: "a",
+			wantFolders: []IndexItem{{Id: "root", Path: "Root"}, {Id: "c", Path: "Root/C"}},
+			wantEntries: []IndexItem{{Id: "e3", Path: "Root/C/E3"}},
+		},
+		{
+			name: "unknown parent", method: http.MethodPost, resourceType: "entry", id: "e4",
+			json: `{"Name":"E4","GroupId":"unknown"}`, wantErr: true,
+		},
+		{
+			name: "unknown entry", method: http.MethodPatch, resourceType: "entry", id: "unknown",
+			json: `{"Name":"E4"}`, wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx := testPathIndex()
+
+			err := idx.update(tt.method, tt.resourceType, tt.id, tt.json)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !slices.Equal(idx.Folders, tt.wantFolders) {
+				t.Errorf("Folders = %v, want %v", idx.Folders, tt.wantFolders)
+			}
+
+			if !slices.Equal(idx.Entries, tt.wantEntries) {
+				t.Errorf("Entries = %v, want %v", idx.Entries, tt.wantEntries)
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package pleasant
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// JsonPath is a parsed JSONPath template as used by kubectl, e.g. '{.Name}' or '{range .Credentials[*]}{.Id}{"\n"}{end}'
+// Expressions support fields ('.Name' or "['Name']"), indexes ('[0]', '[-1]'), wildcards ('[*]' or '.*'),
+// recursive descent ('..Name'), '$' for the root and '@' for the current item of a range
+type JsonPath struct {
+	nodes []jsonPathNode
+}
+
+type jsonPathNode struct {
+	// Text is printed as is if the node has no path
+	text string
+	path *jsonPathExpr
+	// Body of a range node, executed for every value of path
+	body    []jsonPathNode
+	isRange bool
+}
+
+type jsonPathExpr struct {
+	root     bool
+	segments []jsonPathSegment
+}
+
+type jsonPathSegment struct {
+	field     string
+	index     int
+	isIndex   bool
+	wildcard  bool
+	recursive bool
+}
+
+// ParseJsonPath parses a JSONPath template
+func ParseJsonPath(s string) (*JsonPath, error) {
+	stack := [][]jsonPathNode{{}}
+	var ranges []*jsonPathExpr
+
+	for len(s) > 0 {
+		start := strings.IndexByte(s, '{')
+		if start < 0 {
+			stack[len(stack)-1] = append(stack[len(stack)-1], jsonPathNode{text: s})
+			break
+		}
+
+		if start > 0 {
+			stack[len(stack)-1] = append(stack[len(stack)-1], jsonPathNode{text: s[:start]})
+		}
+
+		end := jsonPathClose(s, start+1)
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unclosed '{' in %v", ErrInvalidJsonPath, s)
+		}
+
+		content := strings.TrimSpace(s[start+1 : end])
+		s = s[end+1:]
+
+		switch {
+		case strings.HasPrefix(content, `"`):
+			text, err := strconv.Unquote(content)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid string %v", ErrInvalidJsonPath, content)
+			}
+
+			stack[len(stack)-1] = append(stack[len(stack)-1], jsonPathNode{text: text})
+		case strings.HasPrefix(content, "range "):
+			expr, err := parseJsonPathExpr(strings.TrimSpace(strings.TrimPrefix(content, "range ")))
+			if err != nil {
+				return nil, err
+			}
+
+			ranges = append(ranges, expr)
+			stack = append(stack, []jsonPathNode{})
+		case content == "end":
+			if len(ranges) == 0 {
+				return nil, fmt.Errorf("%w: {end} without {range}", ErrInvalidJsonPath)
+			}
+
+			body := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			stack[len(stack)-1] = append(stack[len(stack)-1], jsonPathNode{path: ranges[len(ranges)-1], body: body, isRange: true})
+			ranges = ranges[:len(ranges)-1]
+		default:
+			expr, err := parseJsonPathExpr(content)
+			if err != nil {
+				return nil, err
+			}
+
+			stack[len(stack)-1] = append(stack[len(stack)-1], jsonPathNode{path: expr})
+		}
+	}
+
+	if len(ranges) > 0 {
+		return nil, fmt.Errorf("%w: {range} without {end}", ErrInvalidJsonPath)
+	}
+
+	return &JsonPath{nodes: stack[0]}, nil
+}
+
+// fieldJsonPath returns a JsonPath that selects the field with the given name, e.g. '{['name']}'
+// The name is not parsed, so it can contain any character
+func fieldJsonPath(name string) *JsonPath {
+	return &JsonPath{nodes: []jsonPathNode{{path: &jsonPathExpr{segments: []jsonPathSegment{{field: name}}}}}}
+}
+
+// jsonPathClose returns the index of the '}' that closes an expression starting at i, skipping quoted strings
+func jsonPathClose(s string, i int) int {
+	var quote byte
+
+	for ; i < len(s); i++ {
+		switch {
+		case quote != 0 && s[i] == '\\':
+			i++
+		case quote != 0 && s[i] == quote:
+			quote = 0
+		case quote != 0:
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == '}':
+			return i
+		}
+	}
+
+	return -1
+}
+
+func parseJsonPathExpr(s string) (*jsonPathExpr, error) {
+	expr := &jsonPathExpr{}
+	orig := s
+
+	switch {
+	case strings.HasPrefix(s, "$"):
+		expr.root = true
+		s = s[1:]
+	case strings.HasPrefix(s, "@"):
+		s = s[1:]
+	case s == "" || !(strings.HasPrefix(s, ".") || strings.HasPrefix(s, "[")):
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJsonPath, orig)
+	}
+
+	for len(s) > 0 {
+		switch {
+		case strings.HasPrefix(s, ".."):
+			name, rest := jsonPathName(s[2:])
+			if name == "" {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidJsonPath, orig)
+			}
+
+			expr.segments = append(expr.segments, jsonPathSegment{field: name, recursive: true})
+			s = rest
+		case strings.HasPrefix(s, "."):
+			name, rest := jsonPathName(s[1:])
+
+			switch name {
+			case "":
+				if rest != "" && !strings.HasPrefix(rest, "[") {
+					return nil, fmt.Errorf("%w: %v", ErrInvalidJsonPath, orig)
+				}
+			case "*":
+				expr.segments = append(expr.segments, jsonPathSegment{wildcard: true})
+			default:
+				expr.segments = append(expr.segments, jsonPathSegment{field: name})
+			}
+
+			s = rest
+		case strings.HasPrefix(s, "["):
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidJsonPath, orig)
+			}
+
+			inner := strings.TrimSpace(s[1:end])
+			s = s[end+1:]
+
+			switch {
+			case inner == "*":
+				expr.segments = append(expr.segments, jsonPathSegment{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				expr.segments = append(expr.segments, jsonPathSegment{field: inner[1 : len(inner)-1]})
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %v", ErrInvalidJsonPath, orig)
+				}
+
+				expr.segments = append(expr.segments, jsonPathSegment{index: n, isIndex: true})
+			}
+		default:
+			return nil, fmt.Errorf("%w: %v", ErrInvalidJsonPath, orig)
+		}
+	}
+
+	return expr, nil
+}
+
+// jsonPathName returns the field name at the start of s and the rest of s
+func jsonPathName(s string) (string, string) {
+	i := strings.IndexAny(s, ".[")
+	if i < 0 {
+		return s, ""
+	}
+
+	return s[:i], s[i:]
+}
+
+// Execute returns the text of the template for data, the values of an expression are separated by spaces
+// Fields that do not exist produce no output
+func (p *JsonPath) Execute(data any) string {
+	var sb strings.Builder
+
+	executeJsonPath(&sb, p.nodes, data, data)
+
+	return sb.String()
+}
+
+func executeJsonPath(sb *strings.Builder, nodes []jsonPathNode, root, current any) {
+	for _, n := range nodes {
+		switch {
+		case n.isRange:
+			for _, v := range n.path.eval(root, current) {
+				executeJsonPath(sb, n.body, root, v)
+			}
+		case n.path != nil:
+			values := n.path.eval(root, current)
+			for i, v := range values {
+				if i > 0 {
+					sb.WriteString(" ")
+				}
+
+				sb.WriteString(FormatJsonValue(v))
+			}
+		default:
+			sb.WriteString(n.text)
+		}
+	}
+}
+
+// Values returns the values of all expressions in the template for data, text is ignored
+func (p *JsonPath) Values(data any) []any {
+	var values []any
+
+	for _, n := range p.nodes {
+		if n.path != nil && !n.isRange {
+			values = append(values, n.path.eval(data, data)...)
+		}
+	}
+
+	return values
+}
+
+func (e *jsonPathExpr) eval(root, current any) []any {
+	values := []any{current}
+	if e.root {
+		values = []any{root}
+	}
+
+	for _, seg := range e.segments {
+		var next []any
+
+		for _, v := range values {
+			next = append(next, seg.apply(v)...)
+		}
+
+		values = next
+	}
+
+	return values
+}
+
+func (seg jsonPathSegment) apply(v any) []any {
+	switch {
+	case seg.recursive:
+		var found []any
+
+		walkJsonValue(v, func(item any) {
+			if m, ok := item.(map[string]any); ok {
+				if f, ok := m[seg.field]; ok {
+					found = append(found, f)
+				}
+			}
+		})
+
+		return found
+	case seg.wildcard:
+		switch t := v.(type) {
+		case map[string]any:
+			var found []any
+			for _, k := range sortedKeys(t) {
+				found = append(found, t[k])
+			}
+
+			return found
+		case []any:
+			return t
+		}
+	case seg.isIndex:
+		if l, ok := v.([]any); ok {
+			i := seg.index
+			if i < 0 {
+				i = len(l) + i
+			}
+
+			if i >= 0 && i < len(l) {
+				return []any{l[i]}
+			}
+		}
+	default:
+		if m, ok := v.(map[string]any); ok {
+			if f, ok := m[seg.field]; ok {
+				return []any{f}
+			}
+		}
+	}
+
+	return nil
+}
+
+// walkJsonValue calls fn for v and all values nested in it, depth first
+func walkJsonValue(v any, fn func(any)) {
+	fn(v)
+
+	switch t := v.(type) {
+	case map[string]any:
+		for _, k := range sortedKeys(t) {
+			walkJsonValue(t[k], fn)
+		}
+	case []any:
+		for _, item := range t {
+			walkJsonValue(item, fn)
+		}
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// FormatJsonValue returns a decoded JSON value as text
+// Strings are returned without quotes, null as an empty string and objects and arrays as JSON
+func FormatJsonValue(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case json.Number:
+		return t.String()
+	case bool:
+		return strconv.FormatBool(t)
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Sprint(t)
+		}
+
+		return string(b)
+	}
+}
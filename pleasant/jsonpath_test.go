@@ -0,0 +1,129 @@
+package pleasant
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+const jsonPathTestData = `{
+	"Id": "f1",
+	"Name": "Root",
+	"Empty": null,
+	"Count": 3,
+	"Enabled": true,
+	"a.b": "dotted",
+	"Tags": [{"Name": "prod"}, {"Name": "db"}],
+	"Credentials": [
+		{"Id": "e1", "Name": "Admin", "CustomUserFields": {"Port": "5432"}},
+		{"Id": "e2", "Name": "Reader"}
+	],
+	"Children": [
+		{"Id": "f2", "Name": "Sub", "Credentials": [{"Id": "e3", "Name": "Nested"}]}
+	]
+}`
+
+func TestJsonPathExecute(t *testing.T) {
+	data, err := decodeJson(jsonPathTestData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{name: "field", template: "{.Name}", want: "Root"},
+		{name: "root", template: "{$.Id}", want: "f1"},
+		{name: "current", template: "{@.Id}", want: "f1"},
+		{name: "text around expressions", template: "id={.Id} name={.Name}", want: "id=f1 name=Root"},
+		{name: "text only", template: "plain", want: "plain"},
+		{name: "number", template: "{.Count}", want: "3"},
+		{name: "bool", template: "{.Enabled}", want: "true"},
+		{name: "null", template: "{.Empty}", want: ""},
+		{name: "object as JSON", template: "{.Credentials[0].CustomUserFields}", want: `{"Port":"5432"}`},
+		{name: "missing field", template: "{.Missing}", want: ""},
+		{name: "missing nested field", template: "{.Missing.Name}", want: ""},
+		{name: "index", template: "{.Credentials[1].Name}", want: "Reader"},
+		{name: "negative index", template: "{.Credentials[-1].Id}", want: "e2"},
+		{name: "index out of range", template: "{.Credentials[5].Id}", want: ""},
+		{name: "index on object", template: "{.Name[0]}", want: ""},
+		{name: "bracket field", template: "{['Name']}", want: "Root"},
+		{name: "double quoted bracket field", template: `{["Id"]}`, want: "f1"},
+		{name: "bracket field with dot", template: "{['a.b']}", want: "dotted"},
+		{name: "wildcard", template: "{.Credentials[*].Id}", want: "e1 e2"},
+		{name: "dot wildcard", template: "{.Tags.*.Name}", want: "prod db"},
+		{name: "wildcard on object is sorted", template: "{.Credentials[0].CustomUserFields.*}", want: "5432"},
+		{name: "recursive descent in key order", template: "{..Id}", want: "f1 f2 e3 e1 e2"},
+		{name: "recursive descent below field", template: "{.Children..Name}", want: "Sub Nested"},
+		{name: "range", template: `{range .Credentials[*]}{.Id}={.Name}{"\n"}{end}`, want: "e1=Admin\ne2=Reader\n"},
+		{name: "range with root", template: `{range .Tags[*]}{$.Id}:{.Name},{end}`, want: "f1:prod,f1:db,"},
+		{name: "nested range", template: `{range .Children[*]}{range .Credentials[*]}{.Name}{end}{end}`, want: "Nested"},
+		{name: "escaped string", template: `{"a\tb"}`, want: "a\tb"},
+		{name: "string with brace", template: `{"}"}{.Id}`, want: "}f1"},
+		{name: "spaces in expression", template: "{ .Name }", want: "Root"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParseJsonPath(tt.template)
+			if err != nil {
+				t.Fatalf("ParseJsonPath(%q) error = %v", tt.template, err)
+			}
+
+			if got := p.Execute(data); got != tt.want {
+				t.Errorf("Execute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseJsonPathInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{name: "unclosed expression", template: "{.Name"},
+		{name: "missing dot", template: "{Name}"},
+		{name: "empty expression", template: "{}"},
+		{name: "unclosed bracket", template: "{.Credentials[0}"},
+		{name: "invalid index", template: "{.Credentials[a]}"},
+		{name: "filter", template: "{.Credentials[?(@.Name=='Admin')].Id}"},
+		{name: "slice", template: "{.Credentials[0:1]}"},
+		{name: "recursive descent without name", template: "{..}"},
+		{name: "invalid string", template: `{"unterminated}`},
+		{name: "range without end", template: "{range .Credentials[*]}{.Id}"},
+		{name: "end without range", template: "{.Id}{end}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseJsonPath(tt.template)
+			if !errors.Is(err, ErrInvalidJsonPath) {
+				t.Errorf("ParseJsonPath(%q) error = %v, want %v", tt.template, err, ErrInvalidJsonPath)
+			}
+		})
+	}
+}
+
+func TestJsonPathValues(t *testing.T) {
+	data, err := decodeJson(jsonPathTestData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := ParseJsonPath("{.Credentials[*]}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, v := range p.Values(data) {
+		ids = append(ids, FormatJsonValue(v.(map[string]any)["Id"]))
+	}
+
+	if want := []string{"e1", "e2"}; !slices.Equal(ids, want) {
+		t.Errorf("Values() ids = %v, want %v", ids, want)
+	}
+}
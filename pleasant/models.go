@@ -1,8 +1,26 @@
 package pleasant
 
+import "encoding/json"
+
 type ConfigFile struct {
-	ServerUrl string `yaml:"serverurl"`
-	Timeout   int    `yaml:"timeout"`
+	ServerUrl      string              `yaml:"serverurl"`
+	Timeout        int                 `yaml:"timeout"`
+	Retries        int                 `yaml:"retries"`
+	TokenKeyFile   string              `yaml:"tokenkeyfile,omitempty"`
+	IndexTtl       int                 `yaml:"indexttl,omitempty"`
+	Resolver       string              `yaml:"resolver,omitempty"`
+	CurrentProfile string              `yaml:"currentprofile,omitempty"`
+	Profiles       map[string]*Profile `yaml:"profiles,omitempty"`
+}
+
+type Profile struct {
+	ServerUrl    string `yaml:"serverurl"`
+	Timeout      int    `yaml:"timeout,omitempty"`
+	Retries      int    `yaml:"retries,omitempty"`
+	TokenFile    string `yaml:"tokenfile,omitempty"`
+	TokenKeyFile string `yaml:"tokenkeyfile,omitempty"`
+	IndexTtl     int    `yaml:"indexttl,omitempty"`
+	Resolver     string `yaml:"resolver,omitempty"`
 }
 
 type TokenFile struct {
@@ -10,8 +28,9 @@ type TokenFile struct {
 }
 
 type Token struct {
-	AccessToken string `yaml:"accesstoken"`
-	ExpiresAt   int64  `yaml:"expiresat"`
+	AccessToken  string `yaml:"accesstoken"`
+	ExpiresAt    int64  `yaml:"expiresat"`
+	RefreshToken string `yaml:"refreshtoken,omitempty"`
 }
 
 type SearchOutput struct {
@@ -40,28 +59,68 @@ type Tag struct {
 }
 
 type Entry struct {
-	Tags     []Tag  `json:"Tags,omitempty"`
-	Id       string `json:"Id,omitempty"`
-	Name     string `json:"Name,omitempty"`
-	Username string `json:"Username,omitempty"`
-	Password string `json:"Password,omitempty"`
-	Url      string `json:"Url,omitempty"`
-	Notes    string `json:"Notes,omitempty"`
-	GroupId  string `json:"GroupId,omitempty"`
-	Expires  string `json:"Expires,omitempty"`
+	CustomUserFields        map[string]string `json:"CustomUserFields,omitempty"`
+	CustomApplicationFields map[string]string `json:"CustomApplicationFields,omitempty"`
+	Attachments             []Attachment      `json:"Attachments,omitempty"`
+	Tags                    []Tag             `json:"Tags,omitempty"`
+	Id                      string            `json:"Id,omitempty"`
+	Name                    string            `json:"Name,omitempty"`
+	Username                string            `json:"Username,omitempty"`
+	Password                string            `json:"Password,omitempty"`
+	Url                     string            `json:"Url,omitempty"`
+	Notes                   string            `json:"Notes,omitempty"`
+	GroupId                 string            `json:"GroupId,omitempty"`
+	Created                 string            `json:"Created,omitempty"`
+	Modified                string            `json:"Modified,omitempty"`
+	Expires                 string            `json:"Expires,omitempty"`
+	HasOtpSecret            bool              `json:"HasOtpSecret,omitempty"`
+	// Extra holds the fields returned by the server that are not part of the model, so they are kept on round-trip
+	Extra map[string]json.RawMessage `json:"-"`
+	// present holds the fields of the model as they were unmarshalled, so explicitly empty values are kept on round-trip
+	present map[string]json.RawMessage
+}
+
+type Attachment struct {
+	CredentialObjectId string `json:"CredentialObjectId,omitempty"`
+	AttachmentId       string `json:"AttachmentId,omitempty"`
+	FileName           string `json:"FileName,omitempty"`
+	// FileData is base64 encoded in JSON
+	FileData []byte `json:"FileData,omitempty"`
+	FileSize int64  `json:"FileSize,omitempty"`
 }
 
 type Folder struct {
-	Children []Entry `json:"Children,omitempty"`
-	Tags     []Tag   `json:"Tags,omitempty"`
-	Id       string  `json:"Id,omitempty"`
-	Name     string  `json:"Name,omitempty"`
-	ParentId string  `json:"ParentId,omitempty"`
-	Notes    string  `json:"Notes,omitempty"`
-	Expires  string  `json:"Expires,omitempty"`
+	CustomUserFields        map[string]string `json:"CustomUserFields,omitempty"`
+	CustomApplicationFields map[string]string `json:"CustomApplicationFields,omitempty"`
+	Children                []Folder          `json:"Children,omitempty"`
+	Credentials             []Entry           `json:"Credentials,omitempty"`
+	Tags                    []Tag             `json:"Tags,omitempty"`
+	Id                      string            `json:"Id,omitempty"`
+	Name                    string            `json:"Name,omitempty"`
+	ParentId                string            `json:"ParentId,omitempty"`
+	Notes                   string            `json:"Notes,omitempty"`
+	Created                 string            `json:"Created,omitempty"`
+	Modified                string            `json:"Modified,omitempty"`
+	Expires                 string            `json:"Expires,omitempty"`
+	// Extra holds the fields returned by the server that are not part of the model, so they are kept on round-trip
+	Extra map[string]json.RawMessage `json:"-"`
+	// present holds the fields of the model as they were unmarshalled, so explicitly empty values are kept on round-trip
+	present map[string]json.RawMessage
 }
 
 type FolderOutput struct {
 	Credentials []Entry
 	Children    []Folder
 }
+
+type DeleteAction string
+
+const (
+	ActionArchive DeleteAction = "Archive"
+	ActionDelete  DeleteAction = "Delete"
+)
+
+type DeleteRequest struct {
+	Action  DeleteAction
+	Comment string
+}
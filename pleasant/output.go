@@ -0,0 +1,327 @@
+package pleasant
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormats are the formats accepted by ParseOutput
+var OutputFormats = []string{"json", "yaml", "table", "wide", "env", "template=", "jsonpath="}
+
+// Output is an output format for the JSON results of the API
+type Output struct {
+	// Format is one of OutputFormats without '='
+	Format string
+	// Template is the Go template or JSONPath template of the template and jsonpath formats
+	Template string
+
+	tmpl     *template.Template
+	jsonPath *JsonPath
+}
+
+// Table describes how a result is shown as a table
+type Table struct {
+	// Rows is a JSONPath template that selects the rows from the result, e.g. '{.Credentials[*]}'
+	// If empty, every item of an array or an object itself is a row
+	Rows    string
+	Columns []Column
+}
+
+// Column is a column of a Table
+type Column struct {
+	Header string
+	// Value is a JSONPath template that is executed for a row, e.g. '{.Name}'
+	Value string
+	// Field is the name of a field of the row that is shown instead of Value, it is used as is
+	Field string
+	// Wide columns are only shown with the wide format
+	Wide bool
+}
+
+// ParseOutput parses an output format like 'yaml' or 'jsonpath={.Name}'
+func ParseOutput(s string) (*Output, error) {
+	format, tmpl, hasTemplate := strings.Cut(s, "=")
+
+	o := &Output{
+		Format:   format,
+		Template: tmpl,
+	}
+
+	switch {
+	case (format == "template" || format == "jsonpath") && !hasTemplate:
+		return nil, fmt.Errorf("%w: %v requires a template, e.g. %v='...'", ErrInvalidOutput, format, format)
+	case format == "template":
+		t, err := template.New("output").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidOutput, err)
+		}
+
+		o.tmpl = t
+	case format == "jsonpath":
+		p, err := ParseJsonPath(tmpl)
+		if err != nil {
+			return nil, err
+		}
+
+		o.jsonPath = p
+	case hasTemplate || !slices.Contains(OutputFormats, format):
+		return nil, fmt.Errorf("%w, must be one of: %v", ErrInvalidOutput, strings.Join(OutputFormats, ", "))
+	}
+
+	return o, nil
+}
+
+// Render returns jsonString in the output format
+// table is used by the table and wide formats, if nil the fields of the result are shown
+func (o *Output) Render(jsonString string, table *Table) (string, error) {
+	data, err := decodeJson(jsonString)
+	if err != nil {
+		return "", err
+	}
+
+	switch o.Format {
+	case "json":
+		b, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return "", err
+		}
+
+		return string(b), nil
+	case "yaml":
+		b, err := yaml.Marshal(yamlCompatible(data))
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSuffix(string(b), "\n"), nil
+	case "table", "wide":
+		if table == nil {
+			data, table = defaultTable(data)
+		}
+
+		return formatTable(data, table, o.Format == "wide")
+	case "env":
+		return formatEnv(data)
+	case "template":
+		var buf bytes.Buffer
+
+		err := o.tmpl.Execute(&buf, data)
+		if err != nil {
+			return "", err
+		}
+
+		return buf.String(), nil
+	case "jsonpath":
+		return o.jsonPath.Execute(data), nil
+	}
+
+	return jsonString, nil
+}
+
+// decodeJson decodes a JSON string, numbers are kept as written
+func decodeJson(jsonString string) (any, error) {
+	d := json.NewDecoder(strings.NewReader(jsonString))
+	d.UseNumber()
+
+	var data any
+
+	err := d.Decode(&data)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// yamlCompatible replaces json.Number values, so numbers are not quoted in YAML
+func yamlCompatible(v any) any {
+	switch t := v.(type) {
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+
+		if f, err := t.Float64(); err == nil {
+			return f
+		}
+
+		return t.String()
+	case map[string]any:
+		m := make(map[string]any, len(t))
+		for k, item := range t {
+			m[k] = yamlCompatible(item)
+		}
+
+		return m
+	case []any:
+		l := make([]any, len(t))
+		for i, item := range t {
+			l[i] = yamlCompatible(item)
+		}
+
+		return l
+	}
+
+	return v
+}
+
+func formatTable(data any, table *Table, wide bool) (string, error) {
+	var rows []any
+
+	switch t := data.(type) {
+	case []any:
+		rows = t
+	default:
+		rows = []any{data}
+	}
+
+	if table.Rows != "" {
+		p, err := ParseJsonPath(table.Rows)
+		if err != nil {
+			return "", err
+		}
+
+		rows = p.Values(data)
+	}
+
+	var headers []string
+	var values []*JsonPath
+
+	for _, c := range table.Columns {
+		if c.Wide && !wide {
+			continue
+		}
+
+		p := fieldJsonPath(c.Field)
+		if c.Field == "" {
+			var err error
+
+			p, err = ParseJsonPath(c.Value)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		headers = append(headers, c.Header)
+		values = append(values, p)
+	}
+
+	var buf bytes.Buffer
+
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		cells := make([]string, len(values))
+		for i, p := range values {
+			cells[i] = tableCell(p.Execute(row))
+		}
+
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	tw.Flush()
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// tableCell keeps a value on a single line of a table
+func tableCell(s string) string {
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ").Replace(s)
+}
+
+// defaultTable returns the rows and table for a result without a Table
+// An object is shown as a row per field, an array with a column for every scalar field of its items
+func defaultTable(data any) (any, *Table) {
+	switch t := data.(type) {
+	case map[string]any:
+		rows := make([]any, 0, len(t))
+		for _, k := range sortedKeys(t) {
+			rows = append(rows, map[string]any{"Field": k, "Value": t[k]})
+		}
+
+		return rows, &Table{
+			Columns: []Column{{Header: "FIELD", Value: "{.Field}"}, {Header: "VALUE", Value: "{.Value}"}},
+		}
+	case []any:
+		var keys []string
+
+		for _, item := range t {
+			m, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			for k, v := range m {
+				switch v.(type) {
+				case map[string]any, []any:
+					continue
+				}
+
+				if !slices.Contains(keys, k) {
+					keys = append(keys, k)
+				}
+			}
+		}
+
+		slices.Sort(keys)
+
+		table := &Table{}
+		for _, k := range keys {
+			table.Columns = append(table.Columns, Column{Header: strings.ToUpper(k), Field: k})
+		}
+
+		if len(table.Columns) > 0 {
+			return data, table
+		}
+	}
+
+	return data, &Table{
+		Columns: []Column{{Header: "VALUE", Value: "{@}"}},
+	}
+}
+
+// formatEnv returns the fields of an object as shell variable assignments, e.g. USERNAME='admin'
+// Nested fields are joined with an underscore, e.g. CUSTOMUSERFIELDS_PORT='5432'
+func formatEnv(data any) (string, error) {
+	if _, ok := data.(map[string]any); !ok {
+		return "", fmt.Errorf("%w: env requires an object", ErrInvalidOutput)
+	}
+
+	var lines []string
+
+	flattenEnv("", data, &lines)
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func flattenEnv(prefix string, v any, lines *[]string) {
+	join := func(name string) string {
+		if prefix == "" {
+			return name
+		}
+
+		return prefix + "_" + name
+	}
+
+	switch t := v.(type) {
+	case map[string]any:
+		for _, k := range sortedKeys(t) {
+			flattenEnv(join(k), t[k], lines)
+		}
+	case []any:
+		for i, item := range t {
+			flattenEnv(join(strconv.Itoa(i)), item, lines)
+		}
+	default:
+		*lines = append(*lines, EnvName(prefix)+"="+ShellQuote(FormatJsonValue(v)))
+	}
+}
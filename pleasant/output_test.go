@@ -0,0 +1,33 @@
+package pleasant
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultTableKeys(t *testing.T) {
+	data, err := decodeJson(`[{"it's": "a", "x]y": "b", "{c}": "c", "plain": "d"}]`)
+	if err != nil {
+		t.Fatalf("decodeJson() error = %v", err)
+	}
+
+	rows, table := defaultTable(data)
+
+	got, err := formatTable(rows, table, false)
+	if err != nil {
+		t.Fatalf("formatTable() error = %v", err)
+	}
+
+	lines := strings.Split(got, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("formatTable() = %q, want a header and a row", got)
+	}
+
+	if fields, want := strings.Fields(lines[0]), []string{"IT'S", "PLAIN", "X]Y", "{C}"}; strings.Join(fields, " ") != strings.Join(want, " ") {
+		t.Errorf("header = %q, want %q", fields, want)
+	}
+
+	if fields, want := strings.Fields(lines[1]), []string{"a", "d", "b", "c"}; strings.Join(fields, " ") != strings.Join(want, " ") {
+		t.Errorf("row = %q, want %q", fields, want)
+	}
+}
@@ -0,0 +1,52 @@
+package pleasant
+
+import "strings"
+
+// pathEscaper escapes the characters that have a special meaning in a path component
+var pathEscaper = strings.NewReplacer(`\`, `\\`, `/`, `\/`)
+
+// SplitPath splits a path into its unescaped components
+// A backslash escapes the next character, so '\/' is a slash and '\\' is a backslash within a component
+func SplitPath(resourcePath string) []string {
+	segments := []string{}
+
+	var sb strings.Builder
+	escaped := false
+
+	for _, r := range resourcePath {
+		switch {
+		case escaped:
+			sb.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '/':
+			segments = append(segments, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	// A trailing backslash does not escape anything and is kept as is
+	if escaped {
+		sb.WriteRune('\\')
+	}
+
+	return append(segments, sb.String())
+}
+
+// EscapePathSegment escapes the name of an entry or folder for use as a path component
+func EscapePathSegment(name string) string {
+	return pathEscaper.Replace(name)
+}
+
+// JoinPath escapes the names of entries and folders and joins them to a path
+func JoinPath(names ...string) string {
+	escaped := make([]string, len(names))
+	for i, name := range names {
+		escaped[i] = EscapePathSegment(name)
+	}
+
+	return strings.Join(escaped, "/")
+}
@@ -0,0 +1,74 @@
+package pleasant
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "Root", want: []string{"Root"}},
+		{path: "Root/Folder/Entry", want: []string{"Root", "Folder", "Entry"}},
+		{path: "Root/Folder/", want: []string{"Root", "Folder", ""}},
+		{path: "", want: []string{""}},
+		{path: `Root/x\/y`, want: []string{"Root", "x/y"}},
+		{path: `Root/https:\/\/app\/admin`, want: []string{"Root", "https://app/admin"}},
+		{path: `Root/back\\slash`, want: []string{"Root", `back\slash`}},
+		{path: `Root/back\\/Entry`, want: []string{"Root", `back\`, "Entry"}},
+		{path: `Root/a\:b`, want: []string{"Root", "a:b"}},
+		{path: `Root/trailing\`, want: []string{"Root", `trailing\`}},
+		{path: "Root//Entry", want: []string{"Root", "", "Entry"}},
+		{path: "Root/Ünïcode/名前", want: []string{"Root", "Ünïcode", "名前"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := SplitPath(tt.path); !slices.Equal(got, tt.want) {
+				t.Errorf("SplitPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapePathSegment(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Entry", want: "Entry"},
+		{name: "x/y", want: `x\/y`},
+		{name: `back\slash`, want: `back\\slash`},
+		{name: `a\/b`, want: `a\\\/b`},
+		{name: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EscapePathSegment(tt.name)
+			if got != tt.want {
+				t.Errorf("EscapePathSegment(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+
+			// An escaped name is a single path component that splits back to the name
+			if split := SplitPath(got); len(split) != 1 || split[0] != tt.name {
+				t.Errorf("SplitPath(%q) = %q, want [%q]", got, split, tt.name)
+			}
+		})
+	}
+}
+
+func TestJoinPath(t *testing.T) {
+	names := []string{"Root", "x/y", `a\b`, "Entry"}
+
+	got := JoinPath(names...)
+	if want := `Root/x\/y/a\\b/Entry`; got != want {
+		t.Errorf("JoinPath(%q) = %q, want %q", names, got, want)
+	}
+
+	if split := SplitPath(got); !slices.Equal(split, names) {
+		t.Errorf("SplitPath(%q) = %q, want %q", got, split, names)
+	}
+}
@@ -1,13 +1,15 @@
 package pleasant
 
 import (
+	"context"
 	"encoding/json"
-	"io"
+	"errors"
+	"net/http"
 	"net/url"
 	"slices"
+	"strconv"
 	"strings"
-
-	"github.com/spf13/cobra"
+	"time"
 )
 
 const (
@@ -20,29 +22,87 @@ const (
 	PathPwStr        = "/api/v5/rest/passwordstrength"
 )
 
+// Environment variables with credentials to log in with
+const (
+	EnvUsername = "PLEASANT_USERNAME"
+	EnvPassword = "PLEASANT_PASSWORD"
+)
+
+// Headers used for two-factor authentication on the token endpoint
+const (
+	HeaderOtp         = "X-Pleasant-OTP"
+	HeaderOtpProvider = "X-Pleasant-OTP-Provider"
+)
+
 type BearerToken struct {
-	AccessToken string `json:"access_token"`
-	ExpiresIn   int    `json:"expires_in"`
+	AccessToken  string `json:"access_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
 }
 
-func GetBearerToken(baseUrl, username, password string) (*BearerToken, error) {
-	path := "/OAuth2/Token"
+// Token returns the bearer token as a Token that expires relative to now
+func (bt *BearerToken) Token() *Token {
+	return &Token{
+		AccessToken:  bt.AccessToken,
+		ExpiresAt:    time.Now().Unix() + int64(bt.ExpiresIn),
+		RefreshToken: bt.RefreshToken,
+	}
+}
 
+// GetBearerToken obtains a bearer token with username and password
+// If two-factor authentication is enabled for the user, an *OtpRequiredError is returned
+// and the request must be repeated with GetBearerTokenWithOtp
+func (c *Client) GetBearerToken(ctx context.Context, username, password string) (*BearerToken, error) {
 	data := url.Values{}
 	data.Add("grant_type", "password")
 	data.Add("username", username)
 	data.Add("password", password)
 
-	res, err := postRequestForm(baseUrl, path, data)
+	return c.requestBearerToken(ctx, data, nil)
+}
+
+// GetBearerTokenWithOtp obtains a bearer token with username, password and a one-time password
+// The provider is the one returned by the server in the *OtpRequiredError of GetBearerToken
+func (c *Client) GetBearerTokenWithOtp(ctx context.Context, username, password, provider, otp string) (*BearerToken, error) {
+	data := url.Values{}
+	data.Add("grant_type", "password")
+	data.Add("username", username)
+	data.Add("password", password)
+
+	header := http.Header{}
+	header.Set(HeaderOtpProvider, provider)
+	header.Set(HeaderOtp, otp)
+
+	return c.requestBearerToken(ctx, data, header)
+}
+
+// RefreshBearerToken obtains a new bearer token with the refresh token of a previous one
+func (c *Client) RefreshBearerToken(ctx context.Context, refreshToken string) (*BearerToken, error) {
+	data := url.Values{}
+	data.Add("grant_type", "refresh_token")
+	data.Add("refresh_token", refreshToken)
+
+	return c.requestBearerToken(ctx, data, nil)
+}
+
+func (c *Client) requestBearerToken(ctx context.Context, data url.Values, header http.Header) (*BearerToken, error) {
+	path := "/OAuth2/Token"
+
+	b, res, err := c.postRequestForm(ctx, path, data, header)
 	if err != nil {
+		// The server challenges for a one-time password if two-factor authentication is enabled
+		if res != nil && strings.EqualFold(res.Header.Get(HeaderOtp), "required") {
+			return nil, &OtpRequiredError{
+				Provider: res.Header.Get(HeaderOtpProvider),
+			}
+		}
+
 		return nil, err
 	}
 
-	defer res.Body.Close()
-
 	bearerToken := &BearerToken{}
 
-	err = decodeJsonBody(res.Body, bearerToken)
+	err = json.Unmarshal(b, bearerToken)
 	if err != nil {
 		return nil, err
 	}
@@ -50,15 +110,8 @@ func GetBearerToken(baseUrl, username, password string) (*BearerToken, error) {
 	return bearerToken, nil
 }
 
-func GetJsonBody(baseUrl, path, bearerToken string) (string, error) {
-	res, err := getRequest(baseUrl, path, bearerToken)
-	if err != nil {
-		return "", err
-	}
-
-	defer res.Body.Close()
-
-	b, err := io.ReadAll(res.Body)
+func (c *Client) GetJsonBody(ctx context.Context, path string) (string, error) {
+	b, err := c.getRequest(ctx, path)
 	if err != nil {
 		return "", err
 	}
@@ -66,68 +119,46 @@ func GetJsonBody(baseUrl, path, bearerToken string) (string, error) {
 	return string(b), nil
 }
 
-func PostJsonString(baseUrl, path, jsonString, bearerToken string) (string, error) {
-	res, err := postRequestJsonString(baseUrl, path, jsonString, bearerToken)
+func (c *Client) PostJsonString(ctx context.Context, path, jsonString string) (string, error) {
+	b, err := c.postRequestJsonString(ctx, path, jsonString)
 	if err != nil {
 		return "", err
 	}
 
-	defer res.Body.Close()
-
-	b, err := io.ReadAll(res.Body)
-	if err != nil {
-		return "", err
-	}
+	c.updatePathIndex(http.MethodPost, path, jsonString, string(b))
 
 	return string(b), nil
 }
 
-func PatchJsonString(baseUrl, path, jsonString, bearerToken string) (string, error) {
-	res, err := patchRequestJsonString(baseUrl, path, jsonString, bearerToken)
+func (c *Client) PatchJsonString(ctx context.Context, path, jsonString string) (string, error) {
+	b, err := c.patchRequestJsonString(ctx, path, jsonString)
 	if err != nil {
 		return "", err
 	}
 
-	defer res.Body.Close()
-
-	b, err := io.ReadAll(res.Body)
-	if err != nil {
-		return "", err
-	}
+	c.updatePathIndex(http.MethodPatch, path, jsonString, string(b))
 
 	return string(b), nil
 }
 
-func DeleteJsonString(baseUrl, path, jsonString, bearerToken string) (string, error) {
-	res, err := deleteRequestJsonString(baseUrl, path, jsonString, bearerToken)
+func (c *Client) DeleteJsonString(ctx context.Context, path, jsonString string) (string, error) {
+	b, err := c.deleteRequestJsonString(ctx, path, jsonString)
 	if err != nil {
 		return "", err
 	}
 
-	defer res.Body.Close()
-
-	b, err := io.ReadAll(res.Body)
-	if err != nil {
-		return "", err
-	}
+	c.updatePathIndex(http.MethodDelete, path, jsonString, string(b))
 
 	return string(b), nil
 }
 
-func PostSearch(baseUrl, query, bearerToken string) (string, error) {
+func (c *Client) PostSearch(ctx context.Context, query string) (string, error) {
 	queryJson, err := json.Marshal(map[string]string{"Search": query})
 	if err != nil {
 		return "", err
 	}
 
-	res, err := postRequestJsonString(baseUrl, PathSearch, string(queryJson), bearerToken)
-	if err != nil {
-		return "", err
-	}
-
-	defer res.Body.Close()
-
-	b, err := io.ReadAll(res.Body)
+	b, err := c.searchRequestJsonString(ctx, string(queryJson))
 	if err != nil {
 		return "", err
 	}
@@ -135,24 +166,57 @@ func PostSearch(baseUrl, query, bearerToken string) (string, error) {
 	return string(b), nil
 }
 
-func GetIdByResourcePath(baseUrl, resourcePath, resourceType, bearerToken string) (string, error) {
+// GetIdByResourcePath returns the id of the entry or folder with the given path
+// Path components are split with SplitPath, so slashes in names must be escaped as '\/'
+// If the client has a path index, it is used before falling back to the configured resolver
+func (c *Client) GetIdByResourcePath(ctx context.Context, resourcePath, resourceType string) (string, error) {
 	if resourceType != "entry" && resourceType != "folder" {
 		return "", ErrInvalidResourceType
 	}
 
-	if resourceType == "folder" {
-		resourcePath = strings.TrimSuffix(resourcePath, "/")
-	}
+	segments := SplitPath(resourcePath)
 
-	splitPath := strings.Split(resourcePath, "/")
+	if resourceType == "folder" && len(segments) > 1 && segments[len(segments)-1] == "" {
+		// A trailing slash is allowed for folders
+		segments = segments[:len(segments)-1]
+	}
 
-	if splitPath[0] != "Root" {
+	if segments[0] != "Root" {
 		return "", ErrPathStartIncorrect
 	}
 
-	resourceName := splitPath[len(splitPath)-1]
+	if c.index != nil {
+		idx, err := c.pathIndex(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		ids := idx.lookup(JoinPath(segments...), resourceType)
 
-	result, err := PostSearch(baseUrl, resourceName, bearerToken)
+		if len(ids) > 1 {
+			return "", &AmbiguousPathError{Path: JoinPath(segments...), Candidates: ids}
+		} else if len(ids) == 1 {
+			return ids[0], nil
+		}
+
+		// The index may be outdated if the tree was changed outside of this client
+	}
+
+	if c.resolver == ResolverTree {
+		return c.walkIdByResourcePath(ctx, segments, resourceType)
+	}
+
+	return c.searchIdByResourcePath(ctx, segments, resourceType)
+}
+
+// searchIdByResourcePath resolves the path by searching for its last component
+func (c *Client) searchIdByResourcePath(ctx context.Context, segments []string, resourceType string) (string, error) {
+	resourceName := segments[len(segments)-1]
+
+	// The search results contain unescaped paths
+	rawPath := strings.Join(segments, "/")
+
+	result, err := c.PostSearch(ctx, resourceName)
 	if err != nil {
 		return "", err
 	}
@@ -162,122 +226,122 @@ func GetIdByResourcePath(baseUrl, resourcePath, resourceType, bearerToken string
 		return "", err
 	}
 
-	var count int
-	var id string
+	ids := []string{}
 
 	if resourceType == "entry" {
 		for _, c := range j.Credentials {
-			if c.Path+resourceName == resourcePath && c.Name == resourceName {
-				count++
-				id = c.Id
+			if c.Path+resourceName == rawPath && c.Name == resourceName {
+				ids = append(ids, c.Id)
 			}
 		}
 	} else if resourceType == "folder" {
 		for _, c := range j.Groups {
-			if strings.TrimSuffix(c.FullPath, "/") == resourcePath && c.Name == resourceName {
-				count++
-				id = c.Id
+			if strings.TrimSuffix(c.FullPath, "/") == rawPath && c.Name == resourceName {
+				ids = append(ids, c.Id)
 			}
 		}
 	}
 
-	if count > 1 {
-		return "", ErrAmbiguousResult
-	} else if count == 0 {
+	if len(ids) > 1 {
+		return "", &AmbiguousPathError{Path: JoinPath(segments...), Candidates: ids}
+	} else if len(ids) == 0 {
 		return "", ErrNotFound
 	}
 
-	return id, nil
+	return ids[0], nil
 }
 
-func GetParentIdByResourcePath(baseUrl, resourcePath, bearerToken string) (string, error) {
-	splitPath := strings.Split(resourcePath, "/")
+// GetParentIdByResourcePath returns the id of the folder that contains the given path
+func (c *Client) GetParentIdByResourcePath(ctx context.Context, resourcePath string) (string, error) {
+	segments := SplitPath(resourcePath)
 
-	if splitPath[0] != "Root" {
+	if segments[0] != "Root" || len(segments) < 2 {
 		return "", ErrPathStartIncorrect
 	}
 
-	parentName := splitPath[len(splitPath)-2]
-
-	result, err := PostSearch(baseUrl, parentName, bearerToken)
-	if err != nil {
-		return "", err
+	id, err := c.GetIdByResourcePath(ctx, JoinPath(segments[:len(segments)-1]...), "folder")
+	if errors.Is(err, ErrNotFound) {
+		return "", ErrParentNotFound
 	}
 
-	j, err := unmarshalSearchResponse(result)
-	if err != nil {
-		return "", err
-	}
+	return id, err
+}
 
-	parentPath := strings.Join(splitPath[:len(splitPath)-1], "/")
+// ResolveResource returns the id and type ('entry' or 'folder') of the resource identified by a path or an id
+// Paths start with 'Root', a path that matches both a folder and an entry resolves to the folder
+func (c *Client) ResolveResource(ctx context.Context, pathOrId string) (string, string, error) {
+	if SplitPath(pathOrId)[0] == "Root" {
+		id, err := c.GetIdByResourcePath(ctx, pathOrId, "folder")
+		if !errors.Is(err, ErrNotFound) {
+			return id, "folder", err
+		}
 
-	var count int
-	var id string
+		id, err = c.GetIdByResourcePath(ctx, pathOrId, "entry")
 
-	for _, c := range j.Groups {
-		if strings.TrimSuffix(c.FullPath, "/") == parentPath && c.Name == parentName {
-			count++
-			id = c.Id
-		}
+		return id, "entry", err
 	}
 
-	if count > 1 {
-		return "", ErrAmbiguousResult
-	} else if count == 0 {
-		return "", ErrParentNotFound
+	_, err := c.GetEntry(ctx, pathOrId)
+	if err == nil {
+		return pathOrId, "entry", nil
+	} else if !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrBadRequest) {
+		return "", "", err
 	}
 
-	return id, nil
+	_, err = c.GetFolderTree(ctx, pathOrId, 1)
+	if errors.Is(err, ErrBadRequest) {
+		// The server responds with 400 for ids that are not a valid GUID
+		return "", "", ErrNotFound
+	} else if err != nil {
+		return "", "", err
+	}
+
+	return pathOrId, "folder", nil
 }
 
-func GetValidPaths(baseUrl, resourcePath string, completeAll bool, bearerToken string) ([]string, []string, error) {
-	splitPath := strings.Split(resourcePath, "/")
+// GetValidPaths returns the paths of the entries and folders that complete the given path
+// The last path component is matched as a prefix, names in the returned paths are escaped
+func (c *Client) GetValidPaths(ctx context.Context, resourcePath string, completeAll bool) ([]string, []string, error) {
+	segments := SplitPath(resourcePath)
 
-	if splitPath[0] != "Root" {
+	if segments[0] != "Root" {
 		return nil, nil, ErrPathStartIncorrect
 	}
 
-	parentPath := parentPath(resourcePath)
-
-	id, err := GetIdByResourcePath(baseUrl, parentPath, "folder", bearerToken)
-	if err != nil {
-		return nil, nil, err
+	parentSegments := segments
+	if len(segments) > 1 {
+		parentSegments = segments[:len(segments)-1]
 	}
 
-	subPath := PathFolders + "/" + id
-
-	j, err := GetJsonBody(baseUrl, subPath, bearerToken)
-	if err != nil {
-		return nil, nil, err
-	}
+	parentPath := JoinPath(parentSegments...)
 
-	fo, err := UnmarshalFolderOutput(j)
+	entryNames, folderNames, err := c.childNames(ctx, parentPath)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	resourceName := splitPath[len(splitPath)-1]
+	resourceName := segments[len(segments)-1]
 
 	entryPaths := []string{}
 	if completeAll {
-		for _, c := range fo.Credentials {
-			if !strings.HasSuffix(resourcePath, "/") && !strings.HasPrefix(c.Name, resourceName) {
+		for _, name := range entryNames {
+			if !strings.HasPrefix(name, resourceName) {
 				// Skip credentials that don't start with the resource name
 				continue
 			}
-			entryPaths = append(entryPaths, parentPath+"/"+c.Name)
+			entryPaths = append(entryPaths, parentPath+"/"+EscapePathSegment(name))
 		}
 
 		slices.Sort(entryPaths)
 	}
 
 	folderPaths := []string{}
-	for _, f := range fo.Children {
-		if !strings.HasSuffix(resourcePath, "/") && !strings.HasPrefix(f.Name, resourceName) {
+	for _, name := range folderNames {
+		if !strings.HasPrefix(name, resourceName) {
 			// Skip folders that don't start with the resource name
 			continue
 		}
-		folderPaths = append(folderPaths, parentPath+"/"+f.Name+"/")
+		folderPaths = append(folderPaths, parentPath+"/"+EscapePathSegment(name)+"/")
 	}
 
 	slices.Sort(folderPaths)
@@ -285,51 +349,55 @@ func GetValidPaths(baseUrl, resourcePath string, completeAll bool, bearerToken s
 	return entryPaths, folderPaths, nil
 }
 
-func CompletePathFlag(toComplete string, completeAll bool) ([]cobra.Completion, cobra.ShellCompDirective) {
-	if toComplete == "" || strings.HasPrefix("Root", toComplete) {
-		return []cobra.Completion{
-			cobra.CompletionWithDesc("Root/", "folder"),
-		}, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
-	}
+// childNames returns the names of the entries and folders directly below the folder with the given path
+func (c *Client) childNames(ctx context.Context, folderPath string) ([]string, []string, error) {
+	if c.index != nil {
+		idx, err := c.pathIndex(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
 
-	if !CheckPrerequisites(IsServerUrlSet(), IsTokenValid()) {
-		ExitFatal(ErrPrereqNotMet)
+		if len(idx.lookup(folderPath, "folder")) > 0 {
+			entryNames, folderNames := idx.children(folderPath)
+			return entryNames, folderNames, nil
+		}
 	}
 
-	baseUrl, bearerToken := LoadConfig()
-
-	ePaths, fPaths, err := GetValidPaths(baseUrl, toComplete, completeAll, bearerToken)
+	id, err := c.GetIdByResourcePath(ctx, folderPath, "folder")
 	if err != nil {
-		return nil, cobra.ShellCompDirectiveError
+		return nil, nil, err
 	}
 
-	completions := make([]cobra.Completion, 0)
-	for _, fp := range fPaths {
-		completions = append(completions, cobra.CompletionWithDesc(fp, "folder"))
+	j, err := c.GetJsonBody(ctx, PathFolders+"/"+id)
+	if err != nil {
+		return nil, nil, err
 	}
-	for _, ep := range ePaths {
-		completions = append(completions, cobra.CompletionWithDesc(ep, "entry"))
+
+	fo, err := UnmarshalFolderOutput(j)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if len(completions) < 1 {
-		return nil, cobra.ShellCompDirectiveError
+	entryNames := []string{}
+	for _, e := range fo.Credentials {
+		entryNames = append(entryNames, e.Name)
 	}
 
-	// There are only entry completions, so we complete with a space
-	if len(fPaths) < 1 {
-		return completions, cobra.ShellCompDirectiveNoFileComp
+	folderNames := []string{}
+	for _, f := range fo.Children {
+		folderNames = append(folderNames, f.Name)
 	}
 
-	return completions, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+	return entryNames, folderNames, nil
 }
 
-func DuplicateEntryExists(baseUrl, jsonString, bearerToken string) (bool, error) {
+func (c *Client) DuplicateEntryExists(ctx context.Context, jsonString string) (bool, error) {
 	input, err := UnmarshalEntry(jsonString)
 	if err != nil {
 		return false, err
 	}
 
-	folder, err := GetJsonBody(baseUrl, PathFolders+"/"+input.GroupId, bearerToken)
+	folder, err := c.GetJsonBody(ctx, PathFolders+"/"+input.GroupId)
 	if err != nil {
 		return false, err
 	}
@@ -348,13 +416,13 @@ func DuplicateEntryExists(baseUrl, jsonString, bearerToken string) (bool, error)
 	return false, nil
 }
 
-func DuplicateEntryId(baseUrl, jsonString, bearerToken string) (string, error) {
+func (c *Client) DuplicateEntryId(ctx context.Context, jsonString string) (string, error) {
 	input, err := UnmarshalEntry(jsonString)
 	if err != nil {
 		return "", err
 	}
 
-	folder, err := GetJsonBody(baseUrl, PathFolders+"/"+input.GroupId, bearerToken)
+	folder, err := c.GetJsonBody(ctx, PathFolders+"/"+input.GroupId)
 	if err != nil {
 		return "", err
 	}
@@ -373,13 +441,13 @@ func DuplicateEntryId(baseUrl, jsonString, bearerToken string) (string, error) {
 	return "", nil
 }
 
-func DuplicateFolderExists(baseUrl, jsonString, bearerToken string) (bool, error) {
+func (c *Client) DuplicateFolderExists(ctx context.Context, jsonString string) (bool, error) {
 	input, err := UnmarshalFolder(jsonString)
 	if err != nil {
 		return false, err
 	}
 
-	folder, err := GetJsonBody(baseUrl, PathFolders+"/"+input.ParentId, bearerToken)
+	folder, err := c.GetJsonBody(ctx, PathFolders+"/"+input.ParentId)
 	if err != nil {
 		return false, err
 	}
@@ -398,13 +466,13 @@ func DuplicateFolderExists(baseUrl, jsonString, bearerToken string) (bool, error
 	return false, nil
 }
 
-func DuplicateFolderId(baseUrl, jsonString, bearerToken string) (string, error) {
+func (c *Client) DuplicateFolderId(ctx context.Context, jsonString string) (string, error) {
 	input, err := UnmarshalFolder(jsonString)
 	if err != nil {
 		return "", err
 	}
 
-	folder, err := GetJsonBody(baseUrl, PathFolders+"/"+input.ParentId, bearerToken)
+	folder, err := c.GetJsonBody(ctx, PathFolders+"/"+input.ParentId)
 	if err != nil {
 		return "", err
 	}
@@ -422,3 +490,205 @@ func DuplicateFolderId(baseUrl, jsonString, bearerToken string) (string, error)
 
 	return "", nil
 }
+
+func (c *Client) GetEntry(ctx context.Context, id string) (*Entry, error) {
+	j, err := c.GetJsonBody(ctx, PathEntry+"/"+id)
+	if err != nil {
+		return nil, err
+	}
+
+	return UnmarshalEntry(j)
+}
+
+func (c *Client) GetEntryPassword(ctx context.Context, id string) (string, error) {
+	j, err := c.GetJsonBody(ctx, PathEntry+"/"+id+"/password")
+	if err != nil {
+		return "", err
+	}
+
+	return unmarshalString(j)
+}
+
+func (c *Client) CreateEntry(ctx context.Context, entry *Entry) (string, error) {
+	j, err := MarshalEntry(entry)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := c.PostJsonString(ctx, PathEntry, j)
+	if err != nil {
+		return "", err
+	}
+
+	return unmarshalString(id)
+}
+
+func (c *Client) PatchEntry(ctx context.Context, id string, entry *Entry) error {
+	j, err := MarshalEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.PatchJsonString(ctx, PathEntry+"/"+id, j)
+
+	return err
+}
+
+func (c *Client) DeleteEntry(ctx context.Context, id string, action DeleteAction) error {
+	j, err := marshalDeleteRequest(action)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.DeleteJsonString(ctx, PathEntry+"/"+id, j)
+
+	return err
+}
+
+// GetEntryAttachments returns the attachments of the entry with the given id, including their contents
+func (c *Client) GetEntryAttachments(ctx context.Context, id string) ([]Attachment, error) {
+	j, err := c.GetJsonBody(ctx, PathEntry+"/"+id+"/attachments")
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := []Attachment{}
+
+	err = json.Unmarshal([]byte(j), &attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// GetEntryAttachment returns the attachment with attachmentId of the entry with the given id, including its contents
+func (c *Client) GetEntryAttachment(ctx context.Context, id, attachmentId string) (*Attachment, error) {
+	j, err := c.GetJsonBody(ctx, PathEntry+"/"+id+"/attachments/"+attachmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &Attachment{}
+
+	err = json.Unmarshal([]byte(j), attachment)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// CreateEntryAttachment adds an attachment to the entry with the given id and returns the id of the attachment
+func (c *Client) CreateEntryAttachment(ctx context.Context, id string, attachment *Attachment) (string, error) {
+	a := *attachment
+	a.CredentialObjectId = id
+	a.AttachmentId = ""
+
+	b, err := json.Marshal(&a)
+	if err != nil {
+		return "", err
+	}
+
+	attachmentId, err := c.PostJsonString(ctx, PathEntry+"/"+id+"/attachments", string(b))
+	if err != nil {
+		return "", err
+	}
+
+	return unmarshalString(attachmentId)
+}
+
+// DeleteEntryAttachment deletes the attachment with attachmentId of the entry with the given id
+func (c *Client) DeleteEntryAttachment(ctx context.Context, id, attachmentId string) error {
+	_, err := c.DeleteJsonString(ctx, PathEntry+"/"+id+"/attachments/"+attachmentId, "")
+
+	return err
+}
+
+func (c *Client) GetFolder(ctx context.Context, id string) (*Folder, error) {
+	j, err := c.GetJsonBody(ctx, PathFolders+"/"+id)
+	if err != nil {
+		return nil, err
+	}
+
+	return UnmarshalFolder(j)
+}
+
+func (c *Client) GetFolders(ctx context.Context) (*Folder, error) {
+	j, err := c.GetJsonBody(ctx, PathFolders)
+	if err != nil {
+		return nil, err
+	}
+
+	return UnmarshalFolder(j)
+}
+
+// GetFolderTree returns a folder with its subfolders and their entries up to depth levels deep
+// A depth of 0 returns all levels
+func (c *Client) GetFolderTree(ctx context.Context, id string, depth int) (*Folder, error) {
+	path := PathFolders + "/" + id
+	if depth > 0 {
+		path = path + "?recurseLevel=" + strconv.Itoa(depth)
+	}
+
+	j, err := c.GetJsonBody(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return UnmarshalFolder(j)
+}
+
+func (c *Client) GetRootFolderId(ctx context.Context) (string, error) {
+	j, err := c.GetJsonBody(ctx, PathRootFolder)
+	if err != nil {
+		return "", err
+	}
+
+	return unmarshalString(j)
+}
+
+func (c *Client) CreateFolder(ctx context.Context, folder *Folder) (string, error) {
+	j, err := MarshalFolder(folder)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := c.PostJsonString(ctx, PathFolders, j)
+	if err != nil {
+		return "", err
+	}
+
+	return unmarshalString(id)
+}
+
+func (c *Client) PatchFolder(ctx context.Context, id string, folder *Folder) error {
+	j, err := MarshalFolder(folder)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.PatchJsonString(ctx, PathFolders+"/"+id, j)
+
+	return err
+}
+
+func (c *Client) DeleteFolder(ctx context.Context, id string, action DeleteAction) error {
+	j, err := marshalDeleteRequest(action)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.DeleteJsonString(ctx, PathFolders+"/"+id, j)
+
+	return err
+}
+
+func (c *Client) Search(ctx context.Context, query string) (*SearchOutput, error) {
+	j, err := c.PostSearch(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalSearchResponse(j)
+}
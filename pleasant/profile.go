@@ -0,0 +1,106 @@
+package pleasant
+
+import (
+	"maps"
+	"slices"
+)
+
+// LoadProfile returns the profile with the given name from the config file
+func LoadProfile(file, name string) (*Profile, error) {
+	c, err := ReadConfigFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	p, ok := c.Profiles[name]
+	if !ok {
+		return nil, ErrProfileNotFound
+	}
+
+	return p, nil
+}
+
+// ListProfiles returns the sorted names of all profiles and the name of the current profile
+func ListProfiles(file string) ([]string, string, error) {
+	c, err := ReadConfigFile(file)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return slices.Sorted(maps.Keys(c.Profiles)), c.CurrentProfile, nil
+}
+
+func AddProfile(file, name string, profile *Profile) error {
+	c, err := ReadConfigFile(file)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := c.Profiles[name]; ok {
+		return ErrProfileExists
+	}
+
+	if c.Profiles == nil {
+		c.Profiles = map[string]*Profile{}
+	}
+
+	c.Profiles[name] = profile
+
+	return writeConfigFile(file, c)
+}
+
+// DeleteProfile deletes a profile, if it is the current profile no profile will be current afterwards
+func DeleteProfile(file, name string) error {
+	c, err := ReadConfigFile(file)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := c.Profiles[name]; !ok {
+		return ErrProfileNotFound
+	}
+
+	delete(c.Profiles, name)
+
+	if c.CurrentProfile == name {
+		c.CurrentProfile = ""
+	}
+
+	return writeConfigFile(file, c)
+}
+
+// UseProfile sets the current profile, an empty name unsets it
+func UseProfile(file, name string) error {
+	c, err := ReadConfigFile(file)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := c.Profiles[name]; !ok && name != "" {
+		return ErrProfileNotFound
+	}
+
+	c.CurrentProfile = name
+
+	return writeConfigFile(file, c)
+}
+
+// WriteProfileConfigFile sets the field key of a profile in the config file to value
+func WriteProfileConfigFile(file, name, key, value string) error {
+	c, err := ReadConfigFile(file)
+	if err != nil {
+		return err
+	}
+
+	p, ok := c.Profiles[name]
+	if !ok {
+		return ErrProfileNotFound
+	}
+
+	err = setField(p, key, value)
+	if err != nil {
+		return err
+	}
+
+	return writeConfigFile(file, c)
+}
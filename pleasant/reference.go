@@ -0,0 +1,206 @@
+package pleasant
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// DefaultReferenceField is the field of an entry that a reference without a field points at
+const DefaultReferenceField = "Password"
+
+// Reference points at a field of an entry, by path or by id
+type Reference struct {
+	// Path is the path of the entry, e.g. 'Root/Folder/Entry', or empty if the entry is referenced by Id
+	Path string
+	Id   string
+	// Field is a field name as accepted by EntryField, e.g. 'Password' or 'CustomUserFields.ApiKey'
+	Field string
+}
+
+// ParseReference parses a reference like 'Root/Folder/Entry:username' or '<id>:username'
+// The field follows the last ':' that is not escaped with a backslash and defaults to the password.
+// A path is resolved like any other path, so names with a '/' or ':' are escaped with a backslash
+func ParseReference(s string) (*Reference, error) {
+	entry, field := s, ""
+
+	if i := lastUnescapedColon(s); i >= 0 {
+		entry, field = s[:i], s[i+1:]
+	}
+
+	if entry == "" || (field == "" && strings.HasSuffix(s, ":")) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReference, s)
+	}
+
+	if field == "" {
+		field = DefaultReferenceField
+	}
+
+	ref := &Reference{
+		Field: field,
+	}
+
+	if SplitPath(entry)[0] == "Root" {
+		ref.Path = entry
+	} else {
+		ref.Id = entry
+	}
+
+	return ref, nil
+}
+
+func lastUnescapedColon(s string) int {
+	last := -1
+	escaped := false
+
+	for i, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ':':
+			last = i
+		}
+	}
+
+	return last
+}
+
+// String returns the reference in the format accepted by ParseReference
+func (r *Reference) String() string {
+	entry := r.Id
+	if r.Path != "" {
+		entry = r.Path
+	}
+
+	return entry + ":" + r.Field
+}
+
+// ResolveReferences returns the values of the fields that refs point at, in the order of refs
+// Every entry is requested once and its password only if a reference points at it
+func (c *Client) ResolveReferences(ctx context.Context, refs []*Reference) ([]string, error) {
+	ids := make([]string, len(refs))
+	fields := map[string][]string{}
+	var order []string
+
+	for i, ref := range refs {
+		id := ref.Id
+
+		if ref.Path != "" {
+			var err error
+
+			id, err = c.GetIdByResourcePath(ctx, ref.Path, "entry")
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", err, ref)
+			}
+		}
+
+		if _, ok := fields[id]; !ok {
+			order = append(order, id)
+		}
+
+		ids[i] = id
+		fields[id] = append(fields[id], ref.Field)
+	}
+
+	values := map[string][]string{}
+
+	for _, id := range order {
+		v, err := c.GetEntryFields(ctx, id, fields[id])
+		if err != nil {
+			return nil, err
+		}
+
+		values[id] = v
+	}
+
+	resolved := make([]string, len(refs))
+
+	for i, id := range ids {
+		resolved[i] = values[id][0]
+		values[id] = values[id][1:]
+	}
+
+	return resolved, nil
+}
+
+// ReferenceUriScheme is the scheme of secret references in templates and config files
+const ReferenceUriScheme = "pleasant://"
+
+// referenceUriPattern matches references in text, they end at whitespace, quotes, brackets, ',' or ';'
+var referenceUriPattern = regexp.MustCompile(regexp.QuoteMeta(ReferenceUriScheme) + "[^\\s\"'`<>()\\[\\]{},;]+")
+
+// ParseReferenceUri parses a reference like 'pleasant://Root/Folder/Entry#username' or 'pleasant://id/<id>#username'
+// The field after '#' defaults to the password. The path and field are percent-decoded, so 'My%20Entry'
+// refers to 'My Entry'. Names with a '/' are escaped with a backslash like in any other path
+func ParseReferenceUri(s string) (*Reference, error) {
+	rest, ok := strings.CutPrefix(s, ReferenceUriScheme)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v, must start with %v", ErrInvalidReference, s, ReferenceUriScheme)
+	}
+
+	entry, field, hasField := strings.Cut(rest, "#")
+
+	entry, err := url.PathUnescape(entry)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReference, s)
+	}
+
+	field, err = url.PathUnescape(field)
+	if err != nil || (hasField && field == "") {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReference, s)
+	}
+
+	if field == "" {
+		field = DefaultReferenceField
+	}
+
+	ref := &Reference{
+		Field: field,
+	}
+
+	switch {
+	case strings.HasPrefix(entry, "id/") && len(entry) > len("id/"):
+		ref.Id = strings.TrimPrefix(entry, "id/")
+	case SplitPath(entry)[0] == "Root":
+		ref.Path = entry
+	default:
+		return nil, fmt.Errorf("%w: %v, must be %vRoot/<path> or %vid/<id>", ErrInvalidReference, s, ReferenceUriScheme, ReferenceUriScheme)
+	}
+
+	return ref, nil
+}
+
+// InjectReferences replaces all references like 'pleasant://Root/Folder/Entry#password' in text with their values
+func (c *Client) InjectReferences(ctx context.Context, text string) (string, error) {
+	uris := referenceUriPattern.FindAllString(text, -1)
+	if len(uris) == 0 {
+		return text, nil
+	}
+
+	refs := make([]*Reference, len(uris))
+
+	for i, uri := range uris {
+		ref, err := ParseReferenceUri(uri)
+		if err != nil {
+			return "", err
+		}
+
+		refs[i] = ref
+	}
+
+	values, err := c.ResolveReferences(ctx, refs)
+	if err != nil {
+		return "", err
+	}
+
+	i := 0
+
+	return referenceUriPattern.ReplaceAllStringFunc(text, func(string) string {
+		i++
+		return values[i-1]
+	}), nil
+}
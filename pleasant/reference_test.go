@@ -0,0 +1,125 @@
+package pleasant
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Reference
+		wantErr error
+	}{
+		{s: "Root/Folder/Entry", want: Reference{Path: "Root/Folder/Entry", Field: "Password"}},
+		{s: "Root/Folder/Entry:username", want: Reference{Path: "Root/Folder/Entry", Field: "username"}},
+		{s: "Root/Folder/Entry:CustomUserFields.Port", want: Reference{Path: "Root/Folder/Entry", Field: "CustomUserFields.Port"}},
+		{s: "Root/Folder/Entry:a:b", want: Reference{Path: "Root/Folder/Entry:a", Field: "b"}},
+		{s: `Root/Folder/a\:b`, want: Reference{Path: `Root/Folder/a\:b`, Field: "Password"}},
+		{s: `Root/Folder/a\:b:Url`, want: Reference{Path: `Root/Folder/a\:b`, Field: "Url"}},
+		{s: `Root/x\/y/https\:\/\/app:Id`, want: Reference{Path: `Root/x\/y/https\:\/\/app`, Field: "Id"}},
+		{s: `Root/back\\:Name`, want: Reference{Path: `Root/back\\`, Field: "Name"}},
+		{s: "Root", want: Reference{Path: "Root", Field: "Password"}},
+		{s: "0b5a0bd6-6f0c-4a8f-9e2d-7a3b0c1d2e3f", want: Reference{Id: "0b5a0bd6-6f0c-4a8f-9e2d-7a3b0c1d2e3f", Field: "Password"}},
+		{s: "0b5a0bd6-6f0c-4a8f-9e2d-7a3b0c1d2e3f:username", want: Reference{Id: "0b5a0bd6-6f0c-4a8f-9e2d-7a3b0c1d2e3f", Field: "username"}},
+		{s: "Rooted/Entry", want: Reference{Id: "Rooted/Entry", Field: "Password"}},
+		{s: "", wantErr: ErrInvalidReference},
+		{s: ":username", wantErr: ErrInvalidReference},
+		{s: "Root/Folder/Entry:", wantErr: ErrInvalidReference},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseReference(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseReference(%q) error = %v, want %v", tt.s, err, tt.wantErr)
+			}
+
+			if err == nil && *got != tt.want {
+				t.Errorf("ParseReference(%q) = %+v, want %+v", tt.s, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReferenceString(t *testing.T) {
+	for _, s := range []string{"Root/Folder/Entry:username", `Root/a\:b:Password`, "id:Url"} {
+		ref, err := ParseReference(s)
+		if err != nil {
+			t.Fatalf("ParseReference(%q) error = %v", s, err)
+		}
+
+		if got := ref.String(); got != s {
+			t.Errorf("String() = %q, want %q", got, s)
+		}
+	}
+}
+
+func TestParseReferenceUri(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Reference
+		wantErr error
+	}{
+		{s: "pleasant://Root/Folder/Entry", want: Reference{Path: "Root/Folder/Entry", Field: "Password"}},
+		{s: "pleasant://Root/Folder/Entry#username", want: Reference{Path: "Root/Folder/Entry", Field: "username"}},
+		{s: "pleasant://Root/My%20Folder/Entry#Custom%20Field", want: Reference{Path: "Root/My Folder/Entry", Field: "Custom Field"}},
+		{s: "pleasant://Root/Folder/100%25", want: Reference{Path: "Root/Folder/100%", Field: "Password"}},
+		{s: `pleasant://Root/x\/y/Entry#Id`, want: Reference{Path: `Root/x\/y/Entry`, Field: "Id"}},
+		{s: "pleasant://Root/Folder/a:b#Url", want: Reference{Path: "Root/Folder/a:b", Field: "Url"}},
+		{s: "pleasant://id/e1", want: Reference{Id: "e1", Field: "Password"}},
+		{s: "pleasant://id/e1#Name", want: Reference{Id: "e1", Field: "Name"}},
+		{s: "pleasant://Root/Folder/Entry#", wantErr: ErrInvalidReference},
+		{s: "pleasant://id/", wantErr: ErrInvalidReference},
+		{s: "pleasant://Folder/Entry", wantErr: ErrInvalidReference},
+		{s: "pleasant://Root/%zz", wantErr: ErrInvalidReference},
+		{s: "Root/Folder/Entry", wantErr: ErrInvalidReference},
+		{s: "https://Root/Folder/Entry", wantErr: ErrInvalidReference},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseReferenceUri(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseReferenceUri(%q) error = %v, want %v", tt.s, err, tt.wantErr)
+			}
+
+			if err == nil && *got != tt.want {
+				t.Errorf("ParseReferenceUri(%q) = %+v, want %+v", tt.s, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReferenceUriPattern(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "password: pleasant://Root/A/B", want: []string{"pleasant://Root/A/B"}},
+		{text: "password: pleasant://Root/A/B\n", want: []string{"pleasant://Root/A/B"}},
+		{text: `"pleasant://Root/A/B#username"`, want: []string{"pleasant://Root/A/B#username"}},
+		{text: "'pleasant://Root/A/B'", want: []string{"pleasant://Root/A/B"}},
+		{text: "`pleasant://Root/A/B`", want: []string{"pleasant://Root/A/B"}},
+		{text: "(pleasant://Root/A/B)", want: []string{"pleasant://Root/A/B"}},
+		{text: "[pleasant://Root/A/B]", want: []string{"pleasant://Root/A/B"}},
+		{text: "{{ pleasant://Root/A/B }}", want: []string{"pleasant://Root/A/B"}},
+		{text: "{{pleasant://Root/A/B}}", want: []string{"pleasant://Root/A/B"}},
+		{text: "<pleasant://Root/A/B>", want: []string{"pleasant://Root/A/B"}},
+		{text: "pleasant://Root/A/B, pleasant://id/e1;", want: []string{"pleasant://Root/A/B", "pleasant://id/e1"}},
+		{text: "a=pleasant://Root/A/B;b=pleasant://Root/A/C#Url", want: []string{"pleasant://Root/A/B", "pleasant://Root/A/C#Url"}},
+		{text: `pleasant://Root/x\/y/B.`, want: []string{`pleasant://Root/x\/y/B.`}},
+		{text: "pleasant://Root/My%20Folder/B", want: []string{"pleasant://Root/My%20Folder/B"}},
+		{text: "no references here", want: nil},
+		{text: "https://Root/A/B", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := referenceUriPattern.FindAllString(tt.text, -1); !slices.Equal(got, tt.want) {
+				t.Errorf("FindAllString(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
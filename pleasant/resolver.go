@@ -0,0 +1,109 @@
+package pleasant
+
+import "context"
+
+// PathResolver is the strategy used to resolve a path to the id of an entry or folder
+type PathResolver string
+
+const (
+	// ResolverSearch searches for the last component of the path and filters the results by their full path
+	// It needs a single request, but fails if the search does not return the resource
+	ResolverSearch PathResolver = "search"
+	// ResolverTree walks the folder tree from the root folder down, one request per path component
+	// It resolves every path that is unambiguous, even if folders with the same name exist elsewhere
+	ResolverTree PathResolver = "tree"
+)
+
+// ParsePathResolver returns the PathResolver for s, an empty string returns ResolverSearch
+func ParsePathResolver(s string) (PathResolver, error) {
+	switch PathResolver(s) {
+	case "", ResolverSearch:
+		return ResolverSearch, nil
+	case ResolverTree:
+		return ResolverTree, nil
+	default:
+		return "", ErrInvalidResolver
+	}
+}
+
+// WithPathResolver sets the strategy used to resolve paths, the default is ResolverSearch
+func WithPathResolver(r PathResolver) Option {
+	return func(c *Client) {
+		c.resolver = r
+	}
+}
+
+// walkIdByResourcePath resolves the path by walking the folder tree from the root folder
+// All folders matching a path component are followed, so a path is only ambiguous if it
+// matches more than one entry or folder in full
+func (c *Client) walkIdByResourcePath(ctx context.Context, segments []string, resourceType string) (string, error) {
+	if resourceType == "entry" && len(segments) < 2 {
+		// The root folder is not an entry
+		return "", ErrNotFound
+	}
+
+	rootId, err := c.GetRootFolderId(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// Ids of the folders that match the path so far
+	ids := []string{rootId}
+
+	folderNames := segments[1:]
+	if resourceType == "entry" {
+		folderNames = segments[1 : len(segments)-1]
+	}
+
+	for _, name := range folderNames {
+		next := []string{}
+
+		for _, id := range ids {
+			// Only the direct children are needed, the full tree below a folder can be huge
+			f, err := c.GetFolderTree(ctx, id, 1)
+			if err != nil {
+				return "", err
+			}
+
+			for _, child := range f.Children {
+				if child.Name == name {
+					next = append(next, child.Id)
+				}
+			}
+		}
+
+		if len(next) == 0 {
+			return "", ErrNotFound
+		}
+
+		ids = next
+	}
+
+	if resourceType == "entry" {
+		name := segments[len(segments)-1]
+		entryIds := []string{}
+
+		for _, id := range ids {
+			f, err := c.GetFolderTree(ctx, id, 1)
+			if err != nil {
+				return "", err
+			}
+
+			for _, e := range f.Credentials {
+				if e.Name == name {
+					entryIds = append(entryIds, e.Id)
+				}
+			}
+		}
+
+		ids = entryIds
+	}
+
+	if len(ids) > 1 {
+		return "", &AmbiguousPathError{Path: JoinPath(segments...), Candidates: ids}
+	} else if len(ids) == 0 {
+		return "", ErrNotFound
+	}
+
+	return ids[0], nil
+}
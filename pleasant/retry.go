@@ -0,0 +1,86 @@
+package pleasant
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how idempotent requests are retried on transient errors
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the initial attempt, 0 disables retries
+	MaxRetries int
+	// BaseDelay is the delay before the first retry, it doubles with each subsequent retry
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between retries, including delays requested through Retry-After
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is used when retries are enabled without specifying delays
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 0,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+}
+
+// backoff returns the delay before the given retry, using exponential backoff with full jitter
+// A positive retryAfter from the server takes precedence over the calculated delay
+func (p RetryPolicy) backoff(retry int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return min(retryAfter, p.MaxDelay)
+	}
+
+	delay := p.BaseDelay << (retry - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return rand.N(delay) + 1
+}
+
+// isTransient reports whether a request that resulted in res and err may succeed when retried
+func isTransient(ctx context.Context, res *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if res == nil {
+		// No response was received, e.g. the connection was reset or the attempt timed out
+		var netErr net.Error
+		return errors.As(err, &netErr)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter returns the delay requested by the Retry-After header of res, if any
+// The header can either be a number of seconds or an HTTP date
+func parseRetryAfter(res *http.Response) time.Duration {
+	if res == nil {
+		return 0
+	}
+
+	h := res.Header.Get("Retry-After")
+	if h == "" {
+		return 0
+	}
+
+	if s, err := strconv.Atoi(h); err == nil {
+		return time.Duration(s) * time.Second
+	}
+
+	if t, err := http.ParseTime(h); err == nil {
+		return time.Until(t)
+	}
+
+	return 0
+}
@@ -0,0 +1,186 @@
+package pleasant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// tokenExpiryMargin is subtracted from the expiry of a token, so it is refreshed before it expires mid-request
+const tokenExpiryMargin = 30 * time.Second
+
+// TokenSource provides the bearer token that is sent with each request
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// RefreshableTokenSource is a TokenSource that can obtain a new bearer token
+// The client refreshes the token and retries the request once when the server responds with 401
+type RefreshableTokenSource interface {
+	TokenSource
+	Refresh(ctx context.Context) (string, error)
+}
+
+// StaticToken is a TokenSource that always returns the same bearer token
+type StaticToken string
+
+func (t StaticToken) Token(ctx context.Context) (string, error) {
+	return string(t), nil
+}
+
+// tokenExpired reports whether the token expires within tokenExpiryMargin
+func tokenExpired(t *Token) bool {
+	return time.Now().Add(tokenExpiryMargin).Unix() > t.ExpiresAt
+}
+
+// RefreshTokenSource is a TokenSource that uses the OAuth2 refresh token to obtain a new
+// access token when the current one has expired or was rejected by the server
+type RefreshTokenSource struct {
+	mu        sync.Mutex
+	client    *Client
+	token     *Token
+	onRefresh func(*Token) error
+}
+
+// WithRefreshableToken sets a token that is refreshed by the client when it has expired
+// onRefresh is called with each new token, e.g. to persist it, and may be nil
+func WithRefreshableToken(token *Token, onRefresh func(*Token) error) Option {
+	return func(c *Client) {
+		c.tokenSource = &RefreshTokenSource{
+			client:    c,
+			token:     token,
+			onRefresh: onRefresh,
+		}
+	}
+}
+
+func (ts *RefreshTokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	expired := tokenExpired(ts.token)
+	canRefresh := ts.token.RefreshToken != ""
+	accessToken := ts.token.AccessToken
+	ts.mu.Unlock()
+
+	if expired && canRefresh {
+		return ts.refreshExpired(ctx)
+	}
+
+	return accessToken, nil
+}
+
+func (ts *RefreshTokenSource) Refresh(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	return ts.refresh(ctx)
+}
+
+// refreshExpired refreshes the token unless another caller refreshed it while waiting for the lock
+func (ts *RefreshTokenSource) refreshExpired(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if !tokenExpired(ts.token) {
+		return ts.token.AccessToken, nil
+	}
+
+	return ts.refresh(ctx)
+}
+
+// refresh obtains a new token with the refresh token, ts.mu must be held
+func (ts *RefreshTokenSource) refresh(ctx context.Context) (string, error) {
+	if ts.token.RefreshToken == "" {
+		return "", ErrUnauthorized
+	}
+
+	bt, err := ts.client.RefreshBearerToken(ctx, ts.token.RefreshToken)
+	if err != nil {
+		return "", fmt.Errorf("%w (refreshing the token failed: %v)", ErrUnauthorized, err)
+	}
+
+	t := bt.Token()
+
+	// Keep using the previous refresh token if the server did not issue a new one
+	if t.RefreshToken == "" {
+		t.RefreshToken = ts.token.RefreshToken
+	}
+
+	ts.token = t
+
+	if ts.onRefresh != nil {
+		err = ts.onRefresh(t)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return t.AccessToken, nil
+}
+
+// CredentialsTokenSource is a TokenSource that logs in with username and password on first use
+// and logs in again when the token has expired, the token is only kept in memory
+type CredentialsTokenSource struct {
+	mu       sync.Mutex
+	client   *Client
+	username string
+	password string
+	token    *Token
+}
+
+// WithCredentials makes the client log in with username and password instead of using a stored token
+func WithCredentials(username, password string) Option {
+	return func(c *Client) {
+		c.tokenSource = &CredentialsTokenSource{
+			client:   c,
+			username: username,
+			password: password,
+		}
+	}
+}
+
+func (ts *CredentialsTokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	token := ts.token
+	ts.mu.Unlock()
+
+	if token == nil || tokenExpired(token) {
+		return ts.refreshExpired(ctx)
+	}
+
+	return token.AccessToken, nil
+}
+
+func (ts *CredentialsTokenSource) Refresh(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	return ts.login(ctx)
+}
+
+// refreshExpired logs in again unless another caller did so while waiting for the lock
+func (ts *CredentialsTokenSource) refreshExpired(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != nil && !tokenExpired(ts.token) {
+		return ts.token.AccessToken, nil
+	}
+
+	return ts.login(ctx)
+}
+
+// login logs in with username and password, ts.mu must be held
+func (ts *CredentialsTokenSource) login(ctx context.Context) (string, error) {
+	bt, err := ts.client.GetBearerToken(ctx, ts.username, ts.password)
+	if errors.Is(err, ErrBadRequest) {
+		return "", ErrInvalidCredentials
+	} else if err != nil {
+		return "", err
+	}
+
+	ts.token = bt.Token()
+
+	return ts.token.AccessToken, nil
+}
@@ -0,0 +1,66 @@
+package pleasant
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRefreshTokenSourceConcurrent(t *testing.T) {
+	var refreshes atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := refreshes.Add(1)
+		fmt.Fprintf(w, `{"access_token": "access-%d", "expires_in": 3600, "refresh_token": "refresh-%d"}`, n, n)
+	}))
+	defer srv.Close()
+
+	expired := &Token{AccessToken: "access-0", ExpiresAt: 0, RefreshToken: "refresh-0"}
+	c := NewClient(srv.URL, WithRefreshableToken(expired, nil))
+	ts := c.tokenSource.(*RefreshTokenSource)
+
+	var wg sync.WaitGroup
+	tokens := make([]string, 10)
+
+	for i := range tokens {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// Callers that found the token expired before the first refresh took the lock
+			token, err := ts.refreshExpired(context.Background())
+			if err != nil {
+				t.Errorf("refreshExpired() error = %v", err)
+			}
+
+			tokens[i] = token
+		}()
+	}
+
+	wg.Wait()
+
+	if n := refreshes.Load(); n != 1 {
+		t.Errorf("refreshed %d times, want 1", n)
+	}
+
+	for _, token := range tokens {
+		if token != "access-1" {
+			t.Errorf("refreshExpired() = %q, want %q", token, "access-1")
+		}
+	}
+
+	if token, err := ts.Token(context.Background()); err != nil || token != "access-1" {
+		t.Errorf("Token() = %q, %v, want %q", token, err, "access-1")
+	}
+
+	// A token rejected by the server is refreshed even if it has not expired
+	token, err := ts.Refresh(context.Background())
+	if err != nil || token != "access-2" {
+		t.Errorf("Refresh() = %q, %v, want %q", token, err, "access-2")
+	}
+}
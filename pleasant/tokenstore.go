@@ -0,0 +1,333 @@
+package pleasant
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	encryptionAesGcm = "aes-256-gcm"
+	kdfPbkdf2        = "pbkdf2-sha256"
+	kdfKeyFile       = "sha256"
+
+	// pbkdf2Iterations follows the OWASP recommendation for PBKDF2-HMAC-SHA256
+	pbkdf2Iterations = 600000
+)
+
+// TokenStore loads, saves and removes the token of pleasant-cli
+type TokenStore interface {
+	// Load returns the stored token, or nil if no token is stored
+	Load() (*Token, error)
+	Save(token *Token) error
+	Clear() error
+	// Location describes where the token is stored
+	Location() string
+}
+
+// FileTokenStore stores the token as plaintext YAML in a file that is only accessible by the owner
+type FileTokenStore struct {
+	path string
+}
+
+// EncryptedTokenStore stores the token encrypted with AES-256-GCM in a file that is only accessible by the owner
+// The key is derived from a passphrase or from the contents of a key file
+// The token is cached once it has been loaded or saved, so the key is not derived again
+type EncryptedTokenStore struct {
+	path       string
+	kdf        string
+	passphrase []byte
+	keyFile    string
+	cached     *Token
+}
+
+// encryptedTokenFile is the format of the token file written by EncryptedTokenStore
+type encryptedTokenFile struct {
+	Encryption string `yaml:"encryption"`
+	Kdf        string `yaml:"kdf"`
+	Iterations int    `yaml:"iterations,omitempty"`
+	Salt       string `yaml:"salt"`
+	Nonce      string `yaml:"nonce"`
+	Data       string `yaml:"data"`
+}
+
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{
+		path: path,
+	}
+}
+
+// NewPassphraseTokenStore returns an EncryptedTokenStore with a key derived from passphrase using PBKDF2
+func NewPassphraseTokenStore(path, passphrase string) *EncryptedTokenStore {
+	return &EncryptedTokenStore{
+		path:       path,
+		kdf:        kdfPbkdf2,
+		passphrase: []byte(passphrase),
+	}
+}
+
+// NewKeyFileTokenStore returns an EncryptedTokenStore with a key derived from the contents of keyFile
+// The key file must only be accessible by the owner and should contain at least 32 random bytes
+// It is read when the token is first loaded or saved
+func NewKeyFileTokenStore(path, keyFile string) *EncryptedTokenStore {
+	return &EncryptedTokenStore{
+		path:    path,
+		kdf:     kdfKeyFile,
+		keyFile: keyFile,
+	}
+}
+
+func (s *FileTokenStore) Load() (*Token, error) {
+	b, err := readTokenFile(s.path)
+	if err != nil || b == nil {
+		return nil, err
+	}
+
+	etf := &encryptedTokenFile{}
+	if yaml.Unmarshal(b, etf) == nil && etf.Encryption != "" {
+		return nil, ErrTokenEncrypted
+	}
+
+	return unmarshalTokenFile(b)
+}
+
+func (s *FileTokenStore) Save(token *Token) error {
+	b, err := yaml.Marshal(&TokenFile{Token: token})
+	if err != nil {
+		return err
+	}
+
+	return WritePrivateFile(s.path, b)
+}
+
+func (s *FileTokenStore) Clear() error {
+	return os.Remove(s.path)
+}
+
+func (s *FileTokenStore) Location() string {
+	return s.path
+}
+
+func (s *EncryptedTokenStore) Load() (*Token, error) {
+	if s.cached != nil {
+		return s.cached, nil
+	}
+
+	b, err := readTokenFile(s.path)
+	if err != nil || b == nil {
+		return nil, err
+	}
+
+	etf := &encryptedTokenFile{}
+
+	err = yaml.Unmarshal(b, etf)
+	if err != nil {
+		return nil, err
+	}
+
+	if etf.Encryption == "" {
+		// The token was stored before encryption was configured, it is encrypted on the next save
+		return unmarshalTokenFile(b)
+	} else if etf.Encryption != encryptionAesGcm || etf.Kdf != s.kdf {
+		return nil, ErrTokenDecrypt
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(etf.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(etf.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(etf.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := s.newAead(salt, etf.Iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := aead.Open(nil, nonce, data, nil)
+	if err != nil {
+		return nil, ErrTokenDecrypt
+	}
+
+	s.cached, err = unmarshalTokenFile(plaintext)
+
+	return s.cached, err
+}
+
+func (s *EncryptedTokenStore) Save(token *Token) error {
+	plaintext, err := yaml.Marshal(&TokenFile{Token: token})
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, 16)
+	rand.Read(salt)
+
+	etf := &encryptedTokenFile{
+		Encryption: encryptionAesGcm,
+		Kdf:        s.kdf,
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+	}
+
+	if s.kdf == kdfPbkdf2 {
+		etf.Iterations = pbkdf2Iterations
+	}
+
+	aead, err := s.newAead(salt, etf.Iterations)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	rand.Read(nonce)
+
+	etf.Nonce = base64.StdEncoding.EncodeToString(nonce)
+	etf.Data = base64.StdEncoding.EncodeToString(aead.Seal(nil, nonce, plaintext, nil))
+
+	b, err := yaml.Marshal(etf)
+	if err != nil {
+		return err
+	}
+
+	err = WritePrivateFile(s.path, b)
+	if err != nil {
+		return err
+	}
+
+	s.cached = token
+
+	return nil
+}
+
+func (s *EncryptedTokenStore) Clear() error {
+	s.cached = nil
+
+	return os.Remove(s.path)
+}
+
+func (s *EncryptedTokenStore) Location() string {
+	return s.path
+}
+
+func (s *EncryptedTokenStore) newAead(salt []byte, iterations int) (cipher.AEAD, error) {
+	if s.keyFile != "" && s.passphrase == nil {
+		err := checkPermissions(s.keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := os.ReadFile(s.keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		s.passphrase = b
+	}
+
+	var key []byte
+
+	switch s.kdf {
+	case kdfPbkdf2:
+		k, err := pbkdf2.Key(sha256.New, string(s.passphrase), salt, iterations, 32)
+		if err != nil {
+			return nil, err
+		}
+
+		key = k
+	default:
+		k := sha256.Sum256(append(salt, s.passphrase...))
+		key = k[:]
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// readTokenFile reads the token file after checking its permissions, a missing file returns nil
+func readTokenFile(path string) ([]byte, error) {
+	err := checkPermissions(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(path)
+}
+
+func unmarshalTokenFile(b []byte) (*Token, error) {
+	tf := &TokenFile{}
+
+	err := yaml.Unmarshal(b, tf)
+	if err != nil {
+		return nil, err
+	}
+
+	return tf.Token, nil
+}
+
+// checkPermissions returns an error if the file is accessible by anyone but its owner
+// File modes do not reflect access control on Windows, so the check is skipped there
+func checkPermissions(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if runtime.GOOS != "windows" && fi.Mode().Perm()&0077 != 0 {
+		return fmt.Errorf("%w: %v has mode %v, run 'chmod 600 %v'", ErrFilePermissions, path, fi.Mode().Perm(), path)
+	}
+
+	return nil
+}
+
+// WritePrivateFile atomically replaces the file with data, only accessible by the owner
+func WritePrivateFile(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(data)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	err = f.Chmod(0600)
+	if err != nil && runtime.GOOS != "windows" {
+		f.Close()
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}